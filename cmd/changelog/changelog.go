@@ -4,50 +4,43 @@ Copyright © 2023 NAME HERE <EMAIL ADDRESS>
 package changelog
 
 import (
-	"errors"
 	"fmt"
-	"github.com/git-releaser/git-releaser/pkg/changelog"
-	"github.com/git-releaser/git-releaser/pkg/config"
-	"github.com/git-releaser/git-releaser/pkg/git"
+	"github.com/git-releaser/git-releaser/pkg/cli"
+	"github.com/git-releaser/git-releaser/pkg/git/common"
 	"github.com/git-releaser/git-releaser/pkg/helpers"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"os"
 )
 
 var ChangeLogCmd = &cobra.Command{
 	Use:   "changelog",
 	Short: "Test the creation of a changelog",
-	Long:  `This command will create a changelog based on the commits since the specified release.`,
+	Long: `This command will create a changelog based on the commits since the specified release.
+Using --from and --to, the changelog for an arbitrary range of tags is created from the local history.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		additionalConfig := make(map[string]string)
-
-		if viper.GetString("repository") != "" {
-			additionalConfig["repository"] = viper.GetString("repository")
+		conf := cli.ReadConfig()
+		if viper.GetString("target_branch") != "" {
+			conf.TargetBranch = viper.GetString("target_branch")
 		}
-
-		if viper.GetInt("project_id") != 0 {
-			additionalConfig["projectId"] = fmt.Sprintf("%d", viper.GetInt("project_id"))
+		if conf.TargetBranch == "" {
+			conf.TargetBranch = "main"
 		}
+		g := cli.NewGitClient(conf)
 
-		g := git.NewGitClient(git.Config{
-			Provider:         viper.GetString("provider"),
-			AccessToken:      viper.GetString("token"),
-			UserId:           viper.GetString("user_id"),
-			ProjectUrl:       viper.GetString("project_url"),
-			ApiUrl:           viper.GetString("api_url"),
-			AdditionalConfig: additionalConfig,
-		})
+		from := viper.GetString("from")
+		to := viper.GetString("to")
 
-		conf, err := config.ReadConfig(viper.ConfigFileUsed())
-		if err != nil {
-			if !errors.Is(err, os.ErrNotExist) {
+		if from != "" || to != "" {
+			commits, err := common.GetCommitsBetween("", from, to)
+			if err != nil {
 				fmt.Println(err)
+				return
 			}
-		}
 
-		if conf.TargetBranch == "" {
-			conf.TargetBranch = "main"
+			log := g.ChangelogFromCommits(common.ConvertCommits(commits), from)
+			fmt.Printf("\nChanges from %s to %s: \n", displayRevision(from, "the beginning"), displayRevision(to, "HEAD"))
+			fmt.Println(log)
+			return
 		}
 
 		sinceVersion := viper.GetString("since_version")
@@ -61,27 +54,36 @@ var ChangeLogCmd = &cobra.Command{
 			}
 		}
 
-		commits, err := g.GetCommitsSinceRelease(conf.Versioning.VersionPrefix + sinceVersion)
+		log, err := g.GenerateChangelog(conf.Versioning.VersionPrefix + sinceVersion)
 		if err != nil {
 			fmt.Println(err)
 		}
-		conventionalCommits := changelog.ParseCommits(commits)
-		log := changelog.GenerateChangelog(conventionalCommits, viper.GetString("project_url"))
 		fmt.Println("Last Version: " + viper.GetString("since_version"))
 		fmt.Println("\nChanges since last version: ")
 		fmt.Println(log)
 	},
 }
 
+func displayRevision(revision string, fallback string) string {
+	if revision == "" {
+		return fallback
+	}
+	return revision
+}
+
 func init() {
-	ChangeLogCmd.Flags().StringP("token", "t", viper.GetString("token"), "access token")
-	ChangeLogCmd.Flags().StringP("api_url", "a", viper.GetString("api_url"), "api url")
-	ChangeLogCmd.Flags().StringP("project_url", "p", viper.GetString("project_url"), "project url")
-	ChangeLogCmd.Flags().IntP("project_id", "i", viper.GetInt("project_id"), "project id")
-	ChangeLogCmd.Flags().StringP("user_id", "u", viper.GetString("user_id"), "user id")
-	ChangeLogCmd.Flags().StringP("provider", "g", "github", "git provider")
-	ChangeLogCmd.Flags().StringP("repository", "r", viper.GetString("repository"), "github repository")
-	ChangeLogCmd.Flags().StringP("target_branch", "b", viper.GetString("target_branch"), "target branch")
+	ChangeLogCmd.PersistentFlags().StringP("token", "t", viper.GetString("token"), "access token")
+	ChangeLogCmd.PersistentFlags().StringP("api_url", "a", viper.GetString("api_url"), "api url")
+	ChangeLogCmd.PersistentFlags().StringP("project_url", "p", viper.GetString("project_url"), "project url")
+	ChangeLogCmd.PersistentFlags().IntP("project_id", "i", viper.GetInt("project_id"), "project id")
+	ChangeLogCmd.PersistentFlags().StringP("user_id", "u", viper.GetString("user_id"), "user id")
+	ChangeLogCmd.PersistentFlags().StringP("provider", "g", "github", "git provider")
+	ChangeLogCmd.PersistentFlags().StringP("repository", "r", viper.GetString("repository"), "github repository")
+	ChangeLogCmd.PersistentFlags().StringP("target_branch", "b", viper.GetString("target_branch"), "target branch")
 	ChangeLogCmd.Flags().StringP("since_version", "l", viper.GetString("since_version"), "version")
+	ChangeLogCmd.Flags().String("from", viper.GetString("from"), "tag to start the changelog after (default: beginning of the history)")
+	ChangeLogCmd.Flags().String("to", viper.GetString("to"), "tag to end the changelog at (default: HEAD)")
 	helpers.BindViperFlags(ChangeLogCmd, viper.GetViper())
+
+	ChangeLogCmd.AddCommand(RebuildCmd)
 }
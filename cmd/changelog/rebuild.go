@@ -0,0 +1,86 @@
+package changelog
+
+import (
+	"fmt"
+	"github.com/git-releaser/git-releaser/pkg/changelog"
+	"github.com/git-releaser/git-releaser/pkg/cli"
+	"github.com/git-releaser/git-releaser/pkg/diff"
+	"github.com/git-releaser/git-releaser/pkg/git/common"
+	"github.com/git-releaser/git-releaser/pkg/helpers"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"os"
+)
+
+var RebuildCmd = &cobra.Command{
+	Use:   "rebuild",
+	Short: "Regenerate the complete changelog from all release tags",
+	Long: `This command walks every release tag of the local repository and regenerates the complete
+historical changelog, e.g. when adopting git-releaser on an existing project.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		conf := cli.ReadConfig()
+		g := cli.NewGitClient(conf)
+
+		tags, err := common.ListVersionTags("", conf.Versioning.VersionPrefix)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		if len(tags) == 0 {
+			fmt.Println("No release tags found")
+			return
+		}
+
+		var releases []changelog.Release
+		previous := ""
+		for _, tag := range tags {
+			commits, err := common.GetCommitsBetween("", previous, tag.Name)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+
+			releases = append(releases, changelog.Release{
+				Version:   tag.Name,
+				Date:      tag.Date,
+				Changelog: g.ChangelogFromCommits(common.ConvertCommits(commits), previous),
+			})
+			previous = tag.Name
+		}
+
+		content := changelog.RenderChangelogFile(releases)
+
+		output := viper.GetString("output")
+		if output == "" {
+			fmt.Println(content)
+			return
+		}
+
+		if viper.GetBool("dry-run") {
+			// A missing changelog is shown as a new file
+			existing, _ := os.ReadFile(output)
+			changes := diff.Unified(output, existing, []byte(content))
+			if changes == "" {
+				fmt.Println("Dry run: " + output + " is up to date")
+				return
+			}
+			fmt.Println("Dry run: would write the following changes:")
+			fmt.Print(changes)
+			return
+		}
+
+		err = os.WriteFile(output, []byte(content), 0644)
+		if err != nil {
+			fmt.Println("Could not write file: " + output)
+			return
+		}
+		fmt.Printf("Changelog for %d releases written to %s\n", len(releases), output)
+	},
+}
+
+func init() {
+	RebuildCmd.Flags().StringP("output", "o", "CHANGELOG.md", "file to write the changelog to")
+	RebuildCmd.Flags().BoolP("dry-run", "d", viper.GetBool("dry-run"), "print the changes to the changelog instead of writing it")
+	helpers.BindViperFlags(RebuildCmd, viper.GetViper())
+}
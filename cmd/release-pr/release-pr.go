@@ -0,0 +1,44 @@
+package release_pr
+
+import (
+	"fmt"
+	"github.com/git-releaser/git-releaser/cmd/update"
+	"github.com/git-releaser/git-releaser/pkg/helpers"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"os"
+)
+
+// ReleasePRCmd creates or updates the release pull request of the next version
+var ReleasePRCmd = &cobra.Command{
+	Use:   "release-pr",
+	Short: "Create or update the release pull request of the next version",
+	Long: `Commit the next version to the release branch and create or update the release pull request for it.
+The release itself is created by the release command once the pull request has been merged.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		w, err := update.NewWorkflow()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		err = w.ReleasePullRequest()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	ReleasePRCmd.Flags().StringP("token", "t", viper.GetString("token"), "Access Token for the Git Provider")
+	ReleasePRCmd.Flags().StringP("api_url", "a", viper.GetString("api_url"), "(optional) API URL for the Git Provider, automatically set for GitHub and GitLab if using the hosted version")
+	ReleasePRCmd.Flags().StringP("project_url", "p", viper.GetString("project_url"), "Project URL for the Git Provider")
+	ReleasePRCmd.Flags().IntP("project_id", "i", viper.GetInt("project_id"), "Project ID when using GitLab")
+	ReleasePRCmd.Flags().StringP("user_id", "u", viper.GetString("user_id"), "User ID")
+	ReleasePRCmd.Flags().StringP("provider", "g", "github", "Git Provider")
+	ReleasePRCmd.Flags().StringP("repository", "r", viper.GetString("repository"), "Repository when using GitHub")
+	ReleasePRCmd.Flags().StringP("target_branch", "b", viper.GetString("target_branch"), "Target Branch (Default: main)")
+	ReleasePRCmd.Flags().BoolP("dry-run", "d", viper.GetBool("dry-run"), "Dry-Run")
+	helpers.BindViperFlags(ReleasePRCmd, viper.GetViper())
+}
@@ -0,0 +1,99 @@
+package release
+
+import (
+	"fmt"
+	"github.com/git-releaser/git-releaser/pkg/cli"
+	"github.com/git-releaser/git-releaser/pkg/git"
+	"github.com/git-releaser/git-releaser/pkg/git/common"
+	"github.com/git-releaser/git-releaser/pkg/helpers"
+	"github.com/git-releaser/git-releaser/pkg/naming"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"os"
+)
+
+var EditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Edit the notes of an existing release",
+	Long: `Edit the notes of an existing release. The new notes are either read from a file, given directly
+or regenerated from the commits between the previous release tag and the release tag using the
+current changelog configuration. Without any of these options, the current notes are printed.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		conf := cli.ReadConfig()
+		g := cli.NewGitClient(conf)
+
+		tag, err := releaseTag()
+		if err != nil {
+			fmt.Println("Could not determine the release tag: " + err.Error())
+			return
+		}
+
+		var notes string
+		switch {
+		case viper.GetString("notes-file") != "":
+			content, err := os.ReadFile(viper.GetString("notes-file"))
+			if err != nil {
+				fmt.Println("Could not read file: " + viper.GetString("notes-file"))
+				return
+			}
+			notes = string(content)
+		case viper.GetString("notes") != "":
+			notes = viper.GetString("notes")
+		case viper.GetBool("regenerate"):
+			notes, err = regenerateNotes(g, tag, conf.Versioning.VersionPrefix)
+			if err != nil {
+				fmt.Println("Could not regenerate the release notes: " + err.Error())
+				return
+			}
+		default:
+			current, err := g.GetReleaseNotes(tag)
+			if err != nil {
+				fmt.Println("Could not get the release notes: " + err.Error())
+				return
+			}
+			fmt.Println(current)
+			return
+		}
+
+		err = g.UpdateReleaseNotes(tag, notes)
+		if err != nil {
+			fmt.Println("Could not update the release notes: " + err.Error())
+		}
+	},
+}
+
+// regenerateNotes creates the release description for the commits between the previous release tag and tag
+func regenerateNotes(g git.Provider, tag string, prefix string) (string, error) {
+	tags, err := common.ListVersionTags("", prefix)
+	if err != nil {
+		return "", err
+	}
+
+	previous := ""
+	found := false
+	for _, t := range tags {
+		if t.Name == tag {
+			found = true
+			break
+		}
+		previous = t.Name
+	}
+	if !found {
+		return "", fmt.Errorf("tag %s not found in the local repository", tag)
+	}
+
+	commits, err := common.GetCommitsBetween("", previous, tag)
+	if err != nil {
+		return "", err
+	}
+
+	cl := g.ChangelogFromCommits(common.ConvertCommits(commits), previous)
+	return naming.CreateReleaseDescription(tag, cl), nil
+}
+
+func init() {
+	EditCmd.Flags().String("notes", viper.GetString("notes"), "New release notes")
+	EditCmd.Flags().String("notes-file", viper.GetString("notes-file"), "File to read the new release notes from")
+	EditCmd.Flags().Bool("regenerate", viper.GetBool("regenerate"), "Regenerate the release notes from the current changelog configuration")
+	helpers.BindViperFlags(EditCmd, viper.GetViper())
+}
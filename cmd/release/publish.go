@@ -0,0 +1,49 @@
+package release
+
+import (
+	"fmt"
+	"github.com/Masterminds/semver"
+	"github.com/git-releaser/git-releaser/pkg/cli"
+	"github.com/git-releaser/git-releaser/pkg/git"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+var PublishCmd = &cobra.Command{
+	Use:   "publish",
+	Short: "Publish a draft release",
+	Long: `Publish the draft release of the version in the manifest or of the given tag, which has been
+created with the draft option of the release configuration. On GitHub the tag of the release is
+created when it is published, on GitLab the release date of the upcoming release is set to now. The floating tags are moved to the
+release after it has been published.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		conf := cli.ReadConfig()
+		g := cli.NewGitClient(conf)
+
+		tag, err := releaseTag()
+		if err != nil {
+			fmt.Println("Could not determine the release tag: " + err.Error())
+			os.Exit(1)
+		}
+
+		err = g.PublishRelease(tag)
+		if err != nil {
+			fmt.Println("Could not publish the release: " + err.Error())
+			os.Exit(1)
+		}
+
+		if conf.Release.FloatingTags {
+			version, err := semver.NewVersion(tag)
+			if err != nil {
+				fmt.Println("Could not move the floating tags: " + err.Error())
+				os.Exit(1)
+			}
+
+			err = git.MoveFloatingTags(g, *version, tag)
+			if err != nil {
+				fmt.Println("Could not move the floating tags: " + err.Error())
+				os.Exit(1)
+			}
+		}
+	},
+}
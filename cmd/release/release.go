@@ -0,0 +1,68 @@
+package release
+
+import (
+	"fmt"
+	"github.com/git-releaser/git-releaser/cmd/update"
+	"github.com/git-releaser/git-releaser/pkg/helpers"
+	"github.com/git-releaser/git-releaser/pkg/manifest"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"os"
+)
+
+// ReleaseCmd creates the release of the version in the manifest, its subcommands work on existing releases
+var ReleaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "Create the release of the version in the manifest and manage existing releases",
+	Long: `Create the release of the version in the manifest once its release pull request has been merged,
+together with the tags of the nested Go modules, the floating tags, the assets and the config updates.
+Nothing is done if the version has been released already. The subcommands manage releases which have
+already been created by git-releaser, the --tag flag only applies to them.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		w, err := update.NewWorkflow()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		released, err := w.Release()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if !released {
+			fmt.Println("No release will be created for version " + w.Versions.CurrentVersion.Original())
+		}
+	},
+}
+
+// releaseTag returns the tag given by the --tag flag or the tag of the version in the manifest
+func releaseTag() (string, error) {
+	if viper.GetString("tag") != "" {
+		return viper.GetString("tag"), nil
+	}
+
+	version, err := manifest.GetCurrentVersion()
+	if err != nil {
+		return "", err
+	}
+	return version.Original(), nil
+}
+
+func init() {
+	ReleaseCmd.PersistentFlags().StringP("token", "t", viper.GetString("token"), "Access Token for the Git Provider")
+	ReleaseCmd.PersistentFlags().StringP("api_url", "a", viper.GetString("api_url"), "(optional) API URL for the Git Provider, automatically set for GitHub and GitLab if using the hosted version")
+	ReleaseCmd.PersistentFlags().StringP("project_url", "p", viper.GetString("project_url"), "Project URL for the Git Provider")
+	ReleaseCmd.PersistentFlags().IntP("project_id", "i", viper.GetInt("project_id"), "Project ID when using GitLab")
+	ReleaseCmd.PersistentFlags().StringP("user_id", "u", viper.GetString("user_id"), "User ID")
+	ReleaseCmd.PersistentFlags().StringP("provider", "g", "github", "Git Provider")
+	ReleaseCmd.PersistentFlags().StringP("repository", "r", viper.GetString("repository"), "Repository when using GitHub")
+	ReleaseCmd.PersistentFlags().BoolP("dry-run", "d", viper.GetBool("dry-run"), "Dry-Run")
+	ReleaseCmd.Flags().StringP("target_branch", "b", viper.GetString("target_branch"), "Target Branch (Default: main)")
+	ReleaseCmd.PersistentFlags().String("tag", viper.GetString("tag"), "Tag of the release (Default: version in the manifest)")
+	helpers.BindViperFlags(ReleaseCmd, viper.GetViper())
+
+	ReleaseCmd.AddCommand(EditCmd)
+	ReleaseCmd.AddCommand(UploadCmd)
+	ReleaseCmd.AddCommand(PublishCmd)
+}
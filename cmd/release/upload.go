@@ -0,0 +1,48 @@
+package release
+
+import (
+	"fmt"
+	"github.com/git-releaser/git-releaser/pkg/assets"
+	"github.com/git-releaser/git-releaser/pkg/cli"
+	"github.com/git-releaser/git-releaser/pkg/config"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+var UploadCmd = &cobra.Command{
+	Use:   "upload [files...]",
+	Short: "Upload assets to an existing release",
+	Long: `Upload files to an existing release. Without arguments, the files of the assets configuration are
+uploaded. If checksums are enabled, a checksum file of the assets and its signature are uploaded as
+well. Assets which already exist in the release with the same name are replaced, so the command
+can be run again after a failed upload.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		conf := cli.ReadConfig()
+		g := cli.NewGitClient(conf)
+
+		tag, err := releaseTag()
+		if err != nil {
+			fmt.Println("Could not determine the release tag: " + err.Error())
+			os.Exit(1)
+		}
+
+		assetConfigs := conf.Assets
+		if len(args) > 0 {
+			assetConfigs = nil
+			for _, arg := range args {
+				assetConfigs = append(assetConfigs, config.AssetConfig{Path: arg})
+			}
+		}
+
+		if len(assetConfigs) == 0 {
+			fmt.Println("No assets configured")
+			return
+		}
+
+		err = assets.Upload(g, tag, assetConfigs, conf.Checksums)
+		if err != nil {
+			fmt.Println("Could not upload the assets: " + err.Error())
+			os.Exit(1)
+		}
+	},
+}
@@ -7,8 +7,11 @@ import (
 	"fmt"
 	"github.com/git-releaser/git-releaser/cmd/changelog"
 	"github.com/git-releaser/git-releaser/cmd/initialize"
+	"github.com/git-releaser/git-releaser/cmd/release"
+	release_pr "github.com/git-releaser/git-releaser/cmd/release-pr"
 	"github.com/git-releaser/git-releaser/cmd/update"
 	update_files "github.com/git-releaser/git-releaser/cmd/update-files"
+	"github.com/git-releaser/git-releaser/pkg/helpers"
 	"github.com/git-releaser/git-releaser/pkg/naming"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -35,6 +38,11 @@ to quickly create a Cobra application.`,
 	// Uncomment the following line if your bare application
 	// has an action associated with it:
 	// Run: func(cmd *cobra.Command, args []string) { },
+
+	// Flags with the same name are defined by several commands, bind the ones of the executed command
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		helpers.BindViperFlags(cmd, viper.GetViper())
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -64,6 +72,8 @@ func init() {
 	rootCmd.AddCommand(update.UpdateCmd)
 	rootCmd.AddCommand(changelog.ChangeLogCmd)
 	rootCmd.AddCommand(update_files.UpdateFilesCmd)
+	rootCmd.AddCommand(release.ReleaseCmd)
+	rootCmd.AddCommand(release_pr.ReleasePRCmd)
 
 	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", fmt.Sprintf("Default config file (%s.%s)", naming.DefaultConfigFileName, "yaml"))
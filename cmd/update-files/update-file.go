@@ -6,12 +6,14 @@ package update_files
 import (
 	"errors"
 	"fmt"
+	"github.com/git-releaser/git-releaser/pkg/cli"
 	"github.com/git-releaser/git-releaser/pkg/config"
-	"github.com/git-releaser/git-releaser/pkg/git"
 	"github.com/git-releaser/git-releaser/pkg/helpers"
+	"github.com/git-releaser/git-releaser/pkg/naming"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"os"
+	"strings"
 )
 
 var UpdateFilesCmd = &cobra.Command{
@@ -19,68 +21,106 @@ var UpdateFilesCmd = &cobra.Command{
 	Short: "updates tagged lines in files (yaml or json) with new strings",
 	Long:  "updates tagged lines in files (yaml or json) with new strings",
 	Run: func(cmd *cobra.Command, args []string) {
-		additionalConfig := make(map[string]string)
+		conf := cli.ReadConfig()
 
-		if viper.GetString("repository") != "" {
-			additionalConfig["repository"] = viper.GetString("repository")
+		if conf.TargetBranch == "" {
+			conf.TargetBranch = "main"
 		}
 
-		if viper.GetInt("project_id") != 0 {
-			additionalConfig["projectId"] = fmt.Sprintf("%d", viper.GetInt("project_id"))
+		replacements, branch, err := readReplacements(&conf)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
 		}
 
-		conf, err := config.ReadConfig(viper.ConfigFileUsed())
+		g := cli.NewGitClient(conf)
+
+		changeset, err := g.ReplaceTags(replacements)
 		if err != nil {
-			if !errors.Is(err, os.ErrNotExist) {
-				fmt.Println(err)
-			}
+			fmt.Println(err)
 		}
 
-		if conf.TargetBranch == "" {
-			conf.TargetBranch = "main"
+		if len(changeset) == 0 {
+			fmt.Println("No tagged lines found, nothing to update")
+			return
 		}
 
-		searchString := viper.GetString("search-tag")
-		replaceString := viper.GetString("replace-string")
-		filePath := viper.GetString("file")
-
-		g := git.NewGitClient(git.Config{
-			Provider:           viper.GetString("provider"),
-			AccessToken:        viper.GetString("token"),
-			UserId:             viper.GetString("user_id"),
-			ProjectUrl:         viper.GetString("project_url"),
-			ApiUrl:             viper.GetString("api_url"),
-			AdditionalConfig:   additionalConfig,
-			PropagationTargets: conf.PropagationTargets,
-			DryRun:             viper.GetBool("dry-run"),
-		})
-
-		changeset, err := g.ReplaceTaggedLines([]string{filePath}, searchString, replaceString)
+		err = g.CommitFile(branch, changeset)
 		if err != nil {
 			fmt.Println(err)
 		}
 
-		err = g.CommitFile(fmt.Sprintf("release/replace-%s-%s", searchString, replaceString), changeset)
+		fmt.Println("Creating merge request")
+		err = g.CheckCreateFileMergeRequest(branch, conf.TargetBranch, naming.CreateFileUpdateTitle(replacements), naming.CreateFileUpdateDescription(replacements))
 		if err != nil {
 			fmt.Println(err)
 		}
 
-		if conf.TargetBranch == "" {
-			conf.TargetBranch = "main"
-		}
+	},
+}
 
-		fmt.Println("Creating merge request")
-		err = g.CheckCreateFileMergeRequest(fmt.Sprintf("release/replace-%s-%s", searchString, replaceString), conf.TargetBranch)
+// readReplacements collects the replacements from the spec file and the flags, and returns them with
+// the branch for the merge request
+func readReplacements(conf *config.Config) ([]config.TagReplacement, string, error) {
+	var replacements []config.TagReplacement
+	var branch string
+
+	files := viper.GetStringSlice("file")
+	excludes := viper.GetStringSlice("exclude")
+
+	if specFile := viper.GetString("spec"); specFile != "" {
+		spec, err := config.ReadReplacementSpec(specFile)
 		if err != nil {
-			fmt.Println(err)
+			return nil, "", err
+		}
+		replacements = append(replacements, spec.Replacements...)
+		branch = spec.Branch
+		if spec.TargetBranch != "" {
+			conf.TargetBranch = spec.TargetBranch
 		}
+	}
 
-	},
+	if viper.GetString("search-tag") != "" {
+		replacements = append(replacements, config.TagReplacement{
+			SearchTag: viper.GetString("search-tag"),
+			Value:     viper.GetString("replace-string"),
+			Files:     files,
+			Exclude:   excludes,
+		})
+	}
+
+	for _, set := range viper.GetStringSlice("set") {
+		tag, value, found := strings.Cut(set, "=")
+		if !found || tag == "" || value == "" {
+			return nil, "", fmt.Errorf("invalid replacement %q, expected <search-tag>=<value>", set)
+		}
+		replacements = append(replacements, config.TagReplacement{SearchTag: tag, Value: value, Files: files, Exclude: excludes})
+	}
+
+	if len(replacements) == 0 {
+		return nil, "", errors.New("no replacements given, use --search-tag, --set or --spec")
+	}
+	for _, replacement := range replacements {
+		if err := config.ValidateSearchTag(replacement.SearchTag); err != nil {
+			return nil, "", err
+		}
+		if len(replacement.Files) == 0 {
+			return nil, "", fmt.Errorf("no files given for search tag %s", replacement.SearchTag)
+		}
+	}
+
+	if branch == "" {
+		branch = naming.CreateFileUpdateBranchName(replacements)
+	}
+	return replacements, branch, nil
 }
 
 func init() {
 	UpdateFilesCmd.Flags().StringP("search-tag", "s", viper.GetString("search-tag"), "Tag to search for in the annotation")
 	UpdateFilesCmd.Flags().StringP("replace-string", "r", viper.GetString("replace-string"), "String to replace the tag with")
-	UpdateFilesCmd.Flags().StringP("file", "f", viper.GetString("file"), "File path to update")
+	UpdateFilesCmd.Flags().StringArray("set", []string{}, "Search tag and value to replace it with as <search-tag>=<value> (can be repeated)")
+	UpdateFilesCmd.Flags().String("spec", viper.GetString("spec"), "YAML file with the replacements to apply")
+	UpdateFilesCmd.Flags().StringSliceP("file", "f", viper.GetStringSlice("file"), "File, directory or glob pattern to update (can be repeated)")
+	UpdateFilesCmd.Flags().StringSlice("exclude", viper.GetStringSlice("exclude"), "Glob pattern of files to skip (can be repeated)")
 	helpers.BindViperFlags(UpdateFilesCmd, viper.GetViper())
 }
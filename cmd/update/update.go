@@ -5,134 +5,45 @@ Copyright © 2023 NAME HERE <EMAIL ADDRESS>
 package update
 
 import (
-	"errors"
 	"fmt"
-	"github.com/git-releaser/git-releaser/pkg/config"
-	"github.com/git-releaser/git-releaser/pkg/git"
+	"github.com/git-releaser/git-releaser/pkg/cli"
 	"github.com/git-releaser/git-releaser/pkg/helpers"
-	"github.com/git-releaser/git-releaser/pkg/versioning"
+	"github.com/git-releaser/git-releaser/pkg/workflow"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"os"
-	"strconv"
 )
 
 // UpdateCmd represents the update command
 var UpdateCmd = &cobra.Command{
 	Use:   "update",
-	Short: "Update the repository with the next version and create a release pull request",
-	Long:  `Update the repository with the next version and create a release pull request.`,
+	Short: "Create the release of the version in the manifest or the release pull request of the next version",
+	Long: `Create the release of the version in the manifest once its release pull request has been merged,
+the release pull request of the next version is created or updated otherwise. The release and release-pr
+commands run these steps on their own.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		additionalConfig := make(map[string]string)
-
-		if viper.GetString("repository") != "" {
-			additionalConfig["repository"] = viper.GetString("repository")
-		}
-
-		if viper.GetInt("project_id") != 0 {
-			additionalConfig["projectId"] = fmt.Sprintf("%d", viper.GetInt("project_id"))
-		}
-
-		conf, err := config.ReadConfig(viper.ConfigFileUsed())
-		if err != nil {
-			if !errors.Is(err, os.ErrNotExist) {
-				fmt.Println(err)
-			}
-		}
-
-		g := git.NewGitClient(git.Config{
-			Provider:           viper.GetString("provider"),
-			AccessToken:        viper.GetString("token"),
-			UserId:             viper.GetString("user_id"),
-			ProjectUrl:         viper.GetString("project_url"),
-			ApiUrl:             viper.GetString("api_url"),
-			AdditionalConfig:   additionalConfig,
-			PropagationTargets: conf.PropagationTargets,
-			DryRun:             viper.GetBool("dry-run"),
-			ConfigUpdates:      conf.ConfigUpdates,
-		})
-
-		if conf.TargetBranch == "" {
-			conf.TargetBranch = "main"
-		}
-
-		v := versioning.NewVersion(conf.Versioning)
-
-		err = v.SetNextVersion()
+		w, err := NewWorkflow()
 		if err != nil {
 			fmt.Println(err)
+			os.Exit(1)
 		}
 
-		versions := v.GetVersions()
-
-		releaseExists, err := g.CheckRelease(versions)
+		err = w.Update()
 		if err != nil {
-			fmt.Println("Could not check for Release: " + err.Error())
-		}
-
-		if !releaseExists {
-			fmt.Println("Running release for version " + versions.CurrentVersion.Original())
-			err = g.CreateRelease(conf.TargetBranch, versions, "")
-			if err != nil {
-				fmt.Println(err)
-			}
-
-			if len(conf.ConfigUpdates) > 0 {
-				for _, update := range conf.ConfigUpdates {
-					if update.ProjectId != 0 {
-						additionalConfig["projectId"] = strconv.Itoa(update.ProjectId)
-					}
-
-					r := git.NewGitClient(git.Config{
-						Provider:         viper.GetString("provider"),
-						AccessToken:      viper.GetString("token"),
-						UserId:           viper.GetString("user_id"),
-						ProjectUrl:       update.Repository,
-						ApiUrl:           viper.GetString("api_url"),
-						AdditionalConfig: additionalConfig,
-						DryRun:           viper.GetBool("dry-run"),
-					})
-
-					changeset, err := r.ReplaceTaggedLines(update.Files, update.SearchTag, versions.CurrentVersion.String())
-					if err != nil {
-						fmt.Println(err)
-					}
-
-					err = r.CommitFile(fmt.Sprintf("release/replace-%s-%s", update.SearchTag, versions.CurrentVersion.String()), changeset)
-					if err != nil {
-						fmt.Println("Could not update the Repository: " + err.Error())
-					}
-
-					err = r.CheckCreateFileMergeRequest(fmt.Sprintf("release/replace-%s-%s", update.SearchTag, versions.CurrentVersion.String()), conf.TargetBranch)
-					if err != nil {
-						fmt.Println("Could not create the Merge Request: " + err.Error())
-					}
-				}
-			}
-			return
-		}
-
-		if !versions.HasNextVersion {
-			fmt.Println("No new version will be created")
-			return
-		}
-
-		branch, err := g.CheckCreateBranch(conf.TargetBranch, versions.NextVersion.Original(), conf.BranchPrefix)
-		if err != nil {
-			fmt.Println("Could not check for Branch: " + err.Error())
+			fmt.Println(err)
+			os.Exit(1)
 		}
+	},
+}
 
-		content := fmt.Sprintf(`{"version": "%s"}`, versions.NextVersion.Original())
-		err = g.CommitManifest(branch, content, versions, conf.ExtraFiles)
-		if err != nil {
-			fmt.Println("Could not update the Repository: " + err.Error())
-		}
+// NewWorkflow creates the workflow for the configuration file and the flags of the executed command
+func NewWorkflow() (*workflow.Workflow, error) {
+	conf := cli.ReadConfig()
+	if viper.GetString("target_branch") != "" {
+		conf.TargetBranch = viper.GetString("target_branch")
+	}
 
-		err = g.CheckCreateReleasePullRequest(branch, conf.TargetBranch, versions)
-		if err != nil {
-			panic(err)
-		}
-	},
+	return workflow.New(conf, cli.GitConfig(conf))
 }
 
 func init() {
@@ -0,0 +1,83 @@
+package assets
+
+import (
+	"fmt"
+	"github.com/git-releaser/git-releaser/pkg/config"
+	"github.com/git-releaser/git-releaser/pkg/helpers"
+	"github.com/go-git/go-billy/v5"
+	"mime"
+	"path"
+)
+
+const defaultContentType = "application/octet-stream"
+
+// Asset is a file which is attached to a release
+type Asset struct {
+	// Name is the file name of the asset in the release
+	Name        string
+	Path        string
+	Label       string
+	ContentType string
+}
+
+// Resolve returns the assets for the files matching the asset configurations. The name of an asset is
+// the base name of its file and has to be unique, as it identifies the asset in the release. When the label
+// of a configuration applies to several files, the name of the file is added to keep the labels unique.
+func Resolve(fs billy.Filesystem, configs []config.AssetConfig) ([]Asset, error) {
+	var assets []Asset
+	names := make(map[string]string)
+
+	for _, assetConfig := range configs {
+		paths, err := helpers.Glob(fs, []string{assetConfig.Path}, assetConfig.Exclude)
+		if err != nil {
+			return nil, err
+		}
+		if len(paths) == 0 {
+			return nil, fmt.Errorf("no files found for asset %s", assetConfig.Path)
+		}
+
+		var matched []Asset
+		for _, p := range paths {
+			info, err := fs.Stat(p)
+			if err != nil {
+				return nil, fmt.Errorf("could not find asset %s: %w", p, err)
+			}
+			if info.IsDir() {
+				continue
+			}
+
+			name := path.Base(p)
+			if existing, ok := names[name]; ok {
+				return nil, fmt.Errorf("assets %s and %s have the same name %s", existing, p, name)
+			}
+			names[name] = p
+
+			matched = append(matched, Asset{
+				Name:        name,
+				Path:        p,
+				Label:       assetConfig.Label,
+				ContentType: contentType(assetConfig.ContentType, name),
+			})
+		}
+
+		if assetConfig.Label != "" && len(matched) > 1 {
+			for i := range matched {
+				matched[i].Label = assetConfig.Label + " (" + matched[i].Name + ")"
+			}
+		}
+		assets = append(assets, matched...)
+	}
+
+	return assets, nil
+}
+
+// contentType returns the configured content type or the type of the file extension
+func contentType(configured string, name string) string {
+	if configured != "" {
+		return configured
+	}
+	if byExtension := mime.TypeByExtension(path.Ext(name)); byExtension != "" {
+		return byExtension
+	}
+	return defaultContentType
+}
@@ -0,0 +1,73 @@
+package assets
+
+import (
+	"github.com/git-releaser/git-releaser/pkg/config"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	"reflect"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	fs := memfs.New()
+	for _, name := range []string{"dist/app-linux-amd64.tar.gz", "dist/app-darwin-arm64.tar.gz", "dist/app.json", "dist/debug/app.bin", "sbom.spdx"} {
+		if err := util.WriteFile(fs, name, []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		configs []config.AssetConfig
+		want    []Asset
+		wantErr bool
+	}{
+		{
+			name: "glob with exclude and content types",
+			configs: []config.AssetConfig{
+				{Path: "dist/*", Exclude: []string{"dist/*.json"}},
+				{Path: "sbom.spdx", Label: "SBOM", ContentType: "text/spdx"},
+			},
+			want: []Asset{
+				{Name: "app-darwin-arm64.tar.gz", Path: "dist/app-darwin-arm64.tar.gz", ContentType: "application/gzip"},
+				{Name: "app-linux-amd64.tar.gz", Path: "dist/app-linux-amd64.tar.gz", ContentType: "application/gzip"},
+				{Name: "sbom.spdx", Path: "sbom.spdx", Label: "SBOM", ContentType: "text/spdx"},
+			},
+		},
+		{
+			name:    "label of several files",
+			configs: []config.AssetConfig{{Path: "dist/*.tar.gz", Label: "Binary"}},
+			want: []Asset{
+				{Name: "app-darwin-arm64.tar.gz", Path: "dist/app-darwin-arm64.tar.gz", Label: "Binary (app-darwin-arm64.tar.gz)", ContentType: "application/gzip"},
+				{Name: "app-linux-amd64.tar.gz", Path: "dist/app-linux-amd64.tar.gz", Label: "Binary (app-linux-amd64.tar.gz)", ContentType: "application/gzip"},
+			},
+		},
+		{
+			name:    "duplicate names",
+			configs: []config.AssetConfig{{Path: "dist/**/app*"}, {Path: "dist/app.json"}},
+			wantErr: true,
+		},
+		{
+			name:    "missing file",
+			configs: []config.AssetConfig{{Path: "dist/missing.zip"}},
+			wantErr: true,
+		},
+		{
+			name:    "no matches",
+			configs: []config.AssetConfig{{Path: "build/*.zip"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Resolve(fs, tt.configs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Resolve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Resolve() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
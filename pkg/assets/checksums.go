@@ -0,0 +1,96 @@
+package assets
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/git-releaser/git-releaser/pkg/config"
+	"github.com/git-releaser/git-releaser/pkg/signing"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const defaultChecksumFile = "SHA256SUMS"
+
+// Checksums returns the SHA-256 checksums of the assets in the format of sha256sum, sorted by name
+func Checksums(assets []Asset) ([]byte, error) {
+	sorted := append([]Asset{}, assets...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	var content bytes.Buffer
+	for _, asset := range sorted {
+		sum, err := fileChecksum(asset.Path)
+		if err != nil {
+			return nil, err
+		}
+		content.WriteString(fmt.Sprintf("%s  %s\n", sum, asset.Name))
+	}
+	return content.Bytes(), nil
+}
+
+func fileChecksum(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// WithChecksums adds the checksum file of the assets to them if checksums are enabled, together with
+// its detached signature if a signing key is configured. The files are written to dir.
+func WithChecksums(assets []Asset, checksumConfig config.ChecksumConfig, dir string) ([]Asset, error) {
+	if !checksumConfig.Enabled || len(assets) == 0 {
+		return assets, nil
+	}
+
+	name := checksumConfig.File
+	if name == "" {
+		name = defaultChecksumFile
+	}
+
+	content, err := Checksums(assets)
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, name)
+	err = os.WriteFile(path, content, 0644)
+	if err != nil {
+		return nil, err
+	}
+	result := append(assets, Asset{Name: name, Path: path, ContentType: "text/plain"})
+
+	if !checksumConfig.Sign.IsSet() {
+		return result, nil
+	}
+
+	signer, err := signing.LoadSigner(checksumConfig.Sign, signing.NamespaceFile)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := signer.Sign(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+
+	signatureName := name + signer.Extension()
+	signaturePath := filepath.Join(dir, signatureName)
+	err = os.WriteFile(signaturePath, signature, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(result, Asset{Name: signatureName, Path: signaturePath, ContentType: "text/plain"}), nil
+}
@@ -0,0 +1,91 @@
+package assets
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"github.com/git-releaser/git-releaser/pkg/config"
+	"golang.org/x/crypto/ssh"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWithChecksums(t *testing.T) {
+	dir := t.TempDir()
+	var assets []Asset
+	for _, name := range []string{"b.txt", "a.txt"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(strings.TrimSuffix(name, ".txt")+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		assets = append(assets, Asset{Name: name, Path: path})
+	}
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := ssh.MarshalPrivateKey(privateKey, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GIT_RELEASER_TEST_KEY", string(pem.EncodeToMemory(block)))
+
+	tests := []struct {
+		name   string
+		config config.ChecksumConfig
+		want   []string
+	}{
+		{name: "disabled", config: config.ChecksumConfig{}, want: []string{"b.txt", "a.txt"}},
+		{name: "default file", config: config.ChecksumConfig{Enabled: true}, want: []string{"b.txt", "a.txt", "SHA256SUMS"}},
+		{
+			name:   "signed",
+			config: config.ChecksumConfig{Enabled: true, File: "checksums.txt", Sign: config.SigningKeyConfig{KeyEnv: "GIT_RELEASER_TEST_KEY"}},
+			want:   []string{"b.txt", "a.txt", "checksums.txt", "checksums.txt.sig"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := t.TempDir()
+			result, err := WithChecksums(append([]Asset{}, assets...), tt.config, out)
+			if err != nil {
+				t.Fatalf("WithChecksums() error = %v", err)
+			}
+
+			var names []string
+			for _, asset := range result {
+				names = append(names, asset.Name)
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Fatalf("WithChecksums() = %v, want %v", names, tt.want)
+			}
+			if !tt.config.Enabled {
+				return
+			}
+
+			content, err := os.ReadFile(result[2].Path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := "87428fc522803d31065e7bce3cf03fe475096631e5e07bbd7a0fde60c4cf25c7  a.txt\n" +
+				"0263829989b6fd954f72baaf2fc64bc2e2f01d692d4de72986ea808f6e99813f  b.txt\n"
+			if string(content) != want {
+				t.Errorf("WithChecksums() checksum file = %q, want %q", content, want)
+			}
+
+			if len(result) > 3 {
+				signature, err := os.ReadFile(result[3].Path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !strings.HasPrefix(string(signature), "-----BEGIN SSH SIGNATURE-----") {
+					t.Errorf("WithChecksums() signature = %q, want an SSH signature", signature)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package assets
+
+import (
+	"github.com/git-releaser/git-releaser/pkg/config"
+	"github.com/go-git/go-billy/v5/osfs"
+	"os"
+)
+
+// Uploader attaches assets to a release, it is implemented by the git providers
+type Uploader interface {
+	UploadReleaseAssets(tag string, assets []Asset) error
+}
+
+// Upload resolves the asset configurations in the working directory, adds the checksum file and its
+// signature if configured and uploads everything to the release with the given tag
+func Upload(uploader Uploader, tag string, configs []config.AssetConfig, checksumConfig config.ChecksumConfig) error {
+	assets, err := Resolve(osfs.New("."), configs)
+	if err != nil {
+		return err
+	}
+
+	dir, err := os.MkdirTemp("", "git-releaser-assets")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	assets, err = WithChecksums(assets, checksumConfig, dir)
+	if err != nil {
+		return err
+	}
+
+	return uploader.UploadReleaseAssets(tag, assets)
+}
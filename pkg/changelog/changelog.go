@@ -3,25 +3,87 @@ package changelog
 import (
 	"bytes"
 	"fmt"
+	"regexp"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 )
 
 // ConventionalCommit represents a conventional commit structure
 type ConventionalCommit struct {
-	Type    string `json:"type"`
-	Scope   string `json:"scope"`
-	Message string `json:"message"`
-	ID      string `json:"id"`
+	Type      string   `json:"type"`
+	Scope     string   `json:"scope"`
+	Message   string   `json:"message"`
+	ID        string   `json:"id"`
+	Author    Author   `json:"author"`
+	CoAuthors []Author `json:"co_authors,omitempty"`
+	// Section overrides the heading of the entry if the type is not a known commit type
+	Section string `json:"section,omitempty"`
+	// URL overrides the link of the entry, e.g. to link a pull request instead of a commit
+	URL string `json:"url,omitempty"`
+	// PatchID, PullRequest and CherryPickOf identify the change independent of its commit
+	PatchID      string `json:"patch_id,omitempty"`
+	PullRequest  int    `json:"pull_request,omitempty"`
+	CherryPickOf string `json:"cherry_pick_of,omitempty"`
 }
 
 type Commit struct {
-	ID        string `json:"id"`
-	Message   string `json:"message"`
-	Timestamp string `json:"timestamp"`
+	ID          string   `json:"id"`
+	Message     string   `json:"message"`
+	Timestamp   string   `json:"timestamp"`
+	AuthorName  string   `json:"author_name"`
+	AuthorEmail string   `json:"author_email"`
+	Username    string   `json:"username,omitempty"`
+	CoAuthors   []Author `json:"co_authors,omitempty"`
+	PatchID     string   `json:"patch_id,omitempty"`
+	PullRequest int      `json:"pull_request,omitempty"`
 }
 
+// PullRequest is a merged pull or merge request which can be used as source of the changelog
+type PullRequest struct {
+	Number int      `json:"number"`
+	Title  string   `json:"title"`
+	Body   string   `json:"body"`
+	URL    string   `json:"url"`
+	Labels []string `json:"labels"`
+	Author Author   `json:"author"`
+}
+
+// Author identifies a person who contributed to a commit
+type Author struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Username string `json:"username,omitempty"`
+}
+
+// Options controls the optional parts of a generated changelog
+type Options struct {
+	Contributors                   bool
+	HighlightFirstTimeContributors bool
+	// KnownContributors holds the keys (see Author.Keys) of everyone who contributed before this release
+	KnownContributors map[string]struct{}
+}
+
+var coAuthorRegex = regexp.MustCompile(`(?im)co-authored-by:\s*([^<\n]+?)\s*<([^>\n]+)>`)
+
+// pullRequestRegexes match the pull request number in squash commits (GitHub), merge commits
+// (GitHub) and the merge request reference added by GitLab
+var pullRequestRegexes = []*regexp.Regexp{
+	regexp.MustCompile(`(?m)\(#(\d+)\)[ \t]*$`),
+	regexp.MustCompile(`(?m)^Merge pull request #(\d+)`),
+	regexp.MustCompile(`(?m)See merge request [\w./-]*!(\d+)`),
+}
+
+// cherryPickRegex matches the trailer added by "git cherry-pick -x"
+var cherryPickRegex = regexp.MustCompile(`\(cherry picked from commit ([0-9a-f]{7,40})\)`)
+
+// releaseNoteBlockRegex matches a fenced ```release-note block in a pull request body
+var releaseNoteBlockRegex = regexp.MustCompile("(?s)```release-notes?[ \t]*\r?\n(.*?)```")
+
+// releaseNoteSectionRegex matches a "## Release Note" section in a pull request body up to the next heading
+var releaseNoteSectionRegex = regexp.MustCompile(`(?ims)^#+[ \t]*release[ \t]+notes?[ \t]*\r?$(.*?)(?:^#|\z)`)
+
 var validCommitTypes = map[string]string{
 	"feat":  "Features",
 	"fix":   "Bug Fixes",
@@ -38,21 +100,57 @@ func ParseCommits(commits []Commit) []ConventionalCommit {
 	}
 
 	for _, commit := range commits {
-		parts := strings.SplitN(commit.Message, ":", 2)
+		coAuthors := commit.CoAuthors
+		if len(coAuthors) == 0 {
+			coAuthors = ParseCoAuthors(commit.Message)
+		}
+		author := Author{Name: commit.AuthorName, Email: commit.AuthorEmail, Username: commit.Username}
+
+		pullRequest := commit.PullRequest
+		if pullRequest == 0 {
+			pullRequest = ParsePullRequestNumber(commit.Message)
+		}
+
+		cherryPickOf := ""
+		if match := cherryPickRegex.FindStringSubmatch(commit.Message); match != nil {
+			cherryPickOf = match[1]
+		}
+
+		// The subject of merge commits is the merge itself, the change is described below
+		message := commit.Message
+		if strings.HasPrefix(message, "Merge pull request") {
+			lines := strings.SplitN(message, "\n", 2)
+			message = ""
+			if len(lines) == 2 {
+				message = strings.TrimSpace(lines[1])
+			}
+		}
+
+		parts := strings.SplitN(message, ":", 2)
 		if len(parts) == 2 {
 			message := strings.Split(strings.TrimSpace(parts[1]), "\n")[0]
 			if slices.Contains(commitTypes, strings.TrimSpace(parts[0])) {
 				message := strings.Split(strings.TrimSpace(parts[1]), "\n")[0]
 				conventionalCommits = append(conventionalCommits, ConventionalCommit{
-					Type:    strings.TrimSpace(parts[0]),
-					Message: message,
-					ID:      commit.ID,
+					Type:         strings.TrimSpace(parts[0]),
+					Message:      message,
+					ID:           commit.ID,
+					Author:       author,
+					CoAuthors:    coAuthors,
+					PatchID:      commit.PatchID,
+					PullRequest:  pullRequest,
+					CherryPickOf: cherryPickOf,
 				})
 			} else {
 				conventionalCommits = append(conventionalCommits, ConventionalCommit{
-					Type:    "other",
-					Message: message,
-					ID:      commit.ID,
+					Type:         "other",
+					Message:      message,
+					ID:           commit.ID,
+					Author:       author,
+					CoAuthors:    coAuthors,
+					PatchID:      commit.PatchID,
+					PullRequest:  pullRequest,
+					CherryPickOf: cherryPickOf,
 				})
 			}
 		}
@@ -60,25 +158,170 @@ func ParseCommits(commits []Commit) []ConventionalCommit {
 	return conventionalCommits
 }
 
+// ParsePullRequestNumber returns the number of the pull or merge request a commit message refers to, or 0
+func ParsePullRequestNumber(message string) int {
+	for _, regex := range pullRequestRegexes {
+		if match := regex.FindStringSubmatch(message); match != nil {
+			number, err := strconv.Atoi(match[1])
+			if err == nil {
+				return number
+			}
+		}
+	}
+	return 0
+}
+
+// ParsePullRequests converts merged pull requests into changelog entries. The entry text is the
+// release note of the pull request body if present, otherwise the title. labelSections maps
+// labels to either a known commit type (e.g. "fix") or a free-form section heading.
+// Pull requests with a release note of "NONE" are skipped.
+func ParsePullRequests(pullRequests []PullRequest, labelSections map[string]string) []ConventionalCommit {
+	var conventionalCommits []ConventionalCommit
+
+	for _, pr := range pullRequests {
+		commitType := "other"
+		message := strings.TrimSpace(pr.Title)
+
+		parts := strings.SplitN(message, ":", 2)
+		if len(parts) == 2 {
+			prefix := strings.TrimSpace(parts[0])
+			if _, exists := validCommitTypes[prefix]; exists {
+				commitType = prefix
+				message = strings.TrimSpace(parts[1])
+			}
+		}
+
+		releaseNote := ExtractReleaseNote(pr.Body)
+		if strings.EqualFold(releaseNote, "none") {
+			continue
+		}
+		if releaseNote != "" {
+			message = releaseNote
+		}
+
+		section := ""
+		for _, label := range pr.Labels {
+			if mapped, exists := labelSections[label]; exists {
+				commitType = mapped
+				if _, known := validCommitTypes[mapped]; !known {
+					section = mapped
+				}
+				break
+			}
+		}
+
+		conventionalCommits = append(conventionalCommits, ConventionalCommit{
+			Type:        commitType,
+			Message:     message,
+			Author:      pr.Author,
+			Section:     section,
+			URL:         pr.URL,
+			PullRequest: pr.Number,
+		})
+	}
+	return conventionalCommits
+}
+
+// ExtractReleaseNote returns the release note of a pull request body, taken either from a
+// fenced ```release-note block or from a "Release Note" section. Multiple lines are joined.
+func ExtractReleaseNote(body string) string {
+	var note string
+	if match := releaseNoteBlockRegex.FindStringSubmatch(body); match != nil {
+		note = match[1]
+	} else if match := releaseNoteSectionRegex.FindStringSubmatch(body); match != nil {
+		note = match[1]
+	}
+
+	var lines []string
+	for _, line := range strings.Split(note, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, " ")
+}
+
+// ParseCoAuthors extracts the authors listed in Co-authored-by trailers of a commit message
+func ParseCoAuthors(message string) []Author {
+	var authors []Author
+	for _, match := range coAuthorRegex.FindAllStringSubmatch(message, -1) {
+		authors = append(authors, Author{
+			Name:  strings.TrimSpace(match[1]),
+			Email: strings.TrimSpace(match[2]),
+		})
+	}
+	return authors
+}
+
+// Keys returns the lower-cased identifiers an author can be recognized by
+func (a Author) Keys() []string {
+	var keys []string
+	for _, key := range []string{a.Username, a.Email, a.Name} {
+		if key != "" {
+			keys = append(keys, strings.ToLower(key))
+		}
+	}
+	return keys
+}
+
+// DisplayName returns the forge username if known, otherwise the author name
+func (a Author) DisplayName() string {
+	if a.Username != "" {
+		return "@" + a.Username
+	}
+	if a.Name != "" {
+		return a.Name
+	}
+	return a.Email
+}
+
+func (a Author) isKnown(known map[string]struct{}) bool {
+	for _, key := range a.Keys() {
+		if _, exists := known[key]; exists {
+			return true
+		}
+	}
+	return false
+}
+
 // GenerateChangelog generates a changelog from conventional commits
 func GenerateChangelog(commits []ConventionalCommit, projectURL string) string {
+	return GenerateChangelogWithOptions(commits, projectURL, Options{})
+}
+
+// GenerateChangelogWithOptions generates a changelog from conventional commits and
+// optionally appends a section listing the contributors of the release
+func GenerateChangelogWithOptions(commits []ConventionalCommit, projectURL string, opts Options) string {
 	// Map to store commits grouped by type
 	commitsByType := make(map[string][]ConventionalCommit)
 	// Slice to store other types of commits
 	var otherCommits []ConventionalCommit
 
-	// Set to keep track of unique commit messages
-	uniqueCommits := make(map[string]struct{})
+	// Set to keep track of the identities of the changes which are already part of the changelog
+	uniqueChanges := make(map[string]struct{})
+	var contributors []Author
 
 	// Group commits by type and filter duplicates
 	for _, commit := range commits {
-		if _, exists := uniqueCommits[commit.Message]; !exists {
-			if _, exists := validCommitTypes[commit.Type]; exists {
-				commitsByType[commit.Type] = append(commitsByType[commit.Type], commit)
-			} else {
-				otherCommits = append(otherCommits, commit)
+		contributors = addContributors(contributors, append([]Author{commit.Author}, commit.CoAuthors...))
+
+		keys := commit.identityKeys()
+		duplicate := false
+		for _, key := range keys {
+			if _, exists := uniqueChanges[key]; exists {
+				duplicate = true
 			}
-			uniqueCommits[commit.Message] = struct{}{}
+			uniqueChanges[key] = struct{}{}
+		}
+		if duplicate {
+			continue
+		}
+
+		if _, exists := validCommitTypes[commit.Type]; exists || commit.Section != "" {
+			commitsByType[commit.Type] = append(commitsByType[commit.Type], commit)
+		} else {
+			otherCommits = append(otherCommits, commit)
 		}
 	}
 
@@ -87,13 +330,15 @@ func GenerateChangelog(commits []ConventionalCommit, projectURL string) string {
 	// Iterate over commit types in sorted order
 	for _, commitType := range getSortedKeys(commitsByType) {
 		// Add heading for the commit type
-		changelogBuffer.WriteString(fmt.Sprintf("## %s\n", validCommitTypes[commitType]))
+		heading, exists := validCommitTypes[commitType]
+		if !exists {
+			heading = commitsByType[commitType][0].Section
+		}
+		changelogBuffer.WriteString(fmt.Sprintf("## %s\n", heading))
 
 		// Iterate over commits for the current type
 		for _, commit := range commitsByType[commitType] {
-			// Add a link to the commit
-			commitLink := fmt.Sprintf("[%s](%s/commit/%s)", commit.Message, projectURL, commit.ID)
-			changelogBuffer.WriteString(fmt.Sprintf("- %s\n", commitLink))
+			changelogBuffer.WriteString(fmt.Sprintf("- %s\n", commit.link(projectURL)))
 		}
 
 		changelogBuffer.WriteString("\n") // Add a newline between sections
@@ -103,15 +348,91 @@ func GenerateChangelog(commits []ConventionalCommit, projectURL string) string {
 	if len(otherCommits) > 0 {
 		changelogBuffer.WriteString("## Others\n")
 		for _, commit := range otherCommits {
-			// Add a link to the commit
-			commitLink := fmt.Sprintf("[%s](%s/commit/%s)", commit.Message, projectURL, commit.ID)
-			changelogBuffer.WriteString(fmt.Sprintf("- %s\n", commitLink))
+			changelogBuffer.WriteString(fmt.Sprintf("- %s\n", commit.link(projectURL)))
+		}
+	}
+
+	if opts.Contributors && len(contributors) > 0 {
+		if len(otherCommits) > 0 {
+			changelogBuffer.WriteString("\n")
+		}
+		changelogBuffer.WriteString("## Contributors\n")
+		for _, contributor := range contributors {
+			if opts.HighlightFirstTimeContributors && !contributor.isKnown(opts.KnownContributors) {
+				changelogBuffer.WriteString(fmt.Sprintf("- %s (first contribution)\n", contributor.DisplayName()))
+			} else {
+				changelogBuffer.WriteString(fmt.Sprintf("- %s\n", contributor.DisplayName()))
+			}
 		}
 	}
 
 	return changelogBuffer.String()
 }
 
+// identityKeys returns the keys which identify the change of an entry: the commit, the commit it was
+// cherry-picked from, its patch-id and the pull request it belongs to. Entries sharing any key are
+// the same change.
+func (c ConventionalCommit) identityKeys() []string {
+	var keys []string
+	if c.ID != "" {
+		keys = append(keys, "commit:"+c.ID)
+	}
+	if c.CherryPickOf != "" {
+		keys = append(keys, "commit:"+c.CherryPickOf)
+	}
+	if c.PatchID != "" {
+		keys = append(keys, "patch:"+c.PatchID)
+	}
+	if c.PullRequest != 0 {
+		keys = append(keys, fmt.Sprintf("pr:%d", c.PullRequest))
+	}
+	return keys
+}
+
+// link returns the markdown link of a changelog entry, pointing to the commit unless a URL is set
+func (c ConventionalCommit) link(projectURL string) string {
+	if c.URL != "" {
+		return fmt.Sprintf("[%s](%s)", c.Message, c.URL)
+	}
+	return fmt.Sprintf("[%s](%s/commit/%s)", c.Message, projectURL, c.ID)
+}
+
+// addContributors appends authors which are not yet part of contributors, merging
+// the details of authors which are already known under one of their keys
+func addContributors(contributors []Author, authors []Author) []Author {
+	for _, author := range authors {
+		if len(author.Keys()) == 0 {
+			continue
+		}
+
+		found := false
+		for i, contributor := range contributors {
+			if sharesKey(contributor, author) {
+				if contributors[i].Username == "" {
+					contributors[i].Username = author.Username
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			contributors = append(contributors, author)
+		}
+	}
+	return contributors
+}
+
+func sharesKey(a Author, b Author) bool {
+	for _, keyA := range a.Keys() {
+		for _, keyB := range b.Keys() {
+			if keyA == keyB {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func getSortedKeys(m map[string][]ConventionalCommit) []string {
 	var keys []string
 	for key := range m {
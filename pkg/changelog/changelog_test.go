@@ -103,3 +103,230 @@ func TestGenerateChangelog(t *testing.T) {
 		t.Errorf("Expected:\n%s\nGot:\n%s", expected, result)
 	}
 }
+
+func TestParseCoAuthors(t *testing.T) {
+	message := "feat: pair programming\n\nCo-authored-by: Jane Doe <jane@example.com>\nco-authored-by: John <john@example.com>"
+
+	expected := []Author{
+		{Name: "Jane Doe", Email: "jane@example.com"},
+		{Name: "John", Email: "john@example.com"},
+	}
+
+	result := ParseCoAuthors(message)
+
+	if len(result) != len(expected) {
+		t.Fatalf("Expected length: %d, got: %d", len(expected), len(result))
+	}
+
+	for i, author := range result {
+		if author != expected[i] {
+			t.Errorf("Expected author: %+v, got: %+v", expected[i], author)
+		}
+	}
+}
+
+func TestGenerateChangelogWithContributors(t *testing.T) {
+	commits := []ConventionalCommit{
+		{
+			Type:      "feat",
+			Message:   "Added new feature",
+			ID:        "abc123",
+			Author:    Author{Name: "Alice", Email: "alice@example.com", Username: "alice"},
+			CoAuthors: []Author{{Name: "Bob", Email: "bob@example.com"}},
+		},
+		{
+			Type:    "fix",
+			Message: "Fixed a bug",
+			ID:      "def456",
+			Author:  Author{Name: "Bob", Email: "bob@example.com", Username: "bob"},
+		},
+	}
+
+	projectURL := "https://github.com/thschue/git-releaser"
+
+	expected := `## Features
+- [Added new feature](https://github.com/thschue/git-releaser/commit/abc123)
+
+## Bug Fixes
+- [Fixed a bug](https://github.com/thschue/git-releaser/commit/def456)
+
+## Contributors
+- @alice
+- @bob (first contribution)
+`
+
+	result := GenerateChangelogWithOptions(commits, projectURL, Options{
+		Contributors:                   true,
+		HighlightFirstTimeContributors: true,
+		KnownContributors:              map[string]struct{}{"alice@example.com": {}},
+	})
+
+	if result != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, result)
+	}
+}
+
+func TestExtractReleaseNote(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "fenced block",
+			body: "Some context\n\n```release-note\nAdded support for\nfoo bars\n```\n",
+			want: "Added support for foo bars",
+		},
+		{
+			name: "section",
+			body: "## Description\nSome context\n\n## Release Note\n\nThe foo is faster now\n\n## Checklist\n- [x] tests",
+			want: "The foo is faster now",
+		},
+		{
+			name: "no release note",
+			body: "Just a description",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractReleaseNote(tt.body); got != tt.want {
+				t.Errorf("ExtractReleaseNote() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePullRequests(t *testing.T) {
+	pullRequests := []PullRequest{
+		{
+			Number: 1,
+			Title:  "feat: add foo",
+			URL:    "https://github.com/thschue/git-releaser/pull/1",
+		},
+		{
+			Number: 2,
+			Title:  "Fix the flaky thing",
+			Body:   "```release-note\nThe thing is no longer flaky\n```",
+			URL:    "https://github.com/thschue/git-releaser/pull/2",
+			Labels: []string{"bug"},
+		},
+		{
+			Number: 3,
+			Title:  "Bump dependencies",
+			URL:    "https://github.com/thschue/git-releaser/pull/3",
+			Labels: []string{"dependencies"},
+		},
+		{
+			Number: 4,
+			Title:  "Refactor internals",
+			Body:   "```release-note\nNONE\n```",
+			URL:    "https://github.com/thschue/git-releaser/pull/4",
+		},
+	}
+
+	labelSections := map[string]string{
+		"bug":          "fix",
+		"dependencies": "Dependency Updates",
+	}
+
+	expected := `## Dependency Updates
+- [Bump dependencies](https://github.com/thschue/git-releaser/pull/3)
+
+## Features
+- [add foo](https://github.com/thschue/git-releaser/pull/1)
+
+## Bug Fixes
+- [The thing is no longer flaky](https://github.com/thschue/git-releaser/pull/2)
+
+`
+
+	result := GenerateChangelog(ParsePullRequests(pullRequests, labelSections), "https://github.com/thschue/git-releaser")
+
+	if result != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, result)
+	}
+}
+
+func TestGenerateChangelogDeduplicatesByIdentity(t *testing.T) {
+	tests := []struct {
+		name     string
+		commits  []Commit
+		expected string
+	}{
+		{
+			name: "separate commits with the same subject",
+			commits: []Commit{
+				{ID: "abc123", Message: "fix: typo", PatchID: "p1"},
+				{ID: "def456", Message: "fix: typo", PatchID: "p2"},
+			},
+			expected: "## Bug Fixes\n" +
+				"- [typo](https://github.com/thschue/git-releaser/commit/abc123)\n" +
+				"- [typo](https://github.com/thschue/git-releaser/commit/def456)\n\n",
+		},
+		{
+			name: "cherry-pick with trailer and different message",
+			commits: []Commit{
+				{ID: "def456", Message: "fix: backport the typo fix\n\n(cherry picked from commit abc123abc123abc123abc123abc123abc123abcd)"},
+				{ID: "abc123abc123abc123abc123abc123abc123abcd", Message: "fix: typo"},
+			},
+			expected: "## Bug Fixes\n" +
+				"- [backport the typo fix](https://github.com/thschue/git-releaser/commit/def456)\n\n",
+		},
+		{
+			name: "cherry-pick without trailer",
+			commits: []Commit{
+				{ID: "def456", Message: "fix: typo (backport)", PatchID: "p1"},
+				{ID: "abc123", Message: "fix: typo", PatchID: "p1"},
+			},
+			expected: "## Bug Fixes\n" +
+				"- [typo (backport)](https://github.com/thschue/git-releaser/commit/def456)\n\n",
+		},
+		{
+			name: "squash commit and the commits of its pull request",
+			commits: []Commit{
+				{ID: "abc123", Message: "feat: add foo (#12)"},
+				{ID: "def456", Message: "feat: add foo", PullRequest: 12},
+				{ID: "ghi789", Message: "feat: add foo tests", PullRequest: 12},
+			},
+			expected: "## Features\n" +
+				"- [add foo (#12)](https://github.com/thschue/git-releaser/commit/abc123)\n\n",
+		},
+		{
+			name: "merge commit of a pull request",
+			commits: []Commit{
+				{ID: "abc123", Message: "Merge pull request #12 from thschue/foo\n\nfeat: add foo"},
+				{ID: "def456", Message: "feat: add foo", PullRequest: 12},
+			},
+			expected: "## Features\n" +
+				"- [add foo](https://github.com/thschue/git-releaser/commit/abc123)\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := GenerateChangelog(ParseCommits(tt.commits), "https://github.com/thschue/git-releaser")
+			if result != tt.expected {
+				t.Errorf("Expected:\n%s\nGot:\n%s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestParsePullRequestNumber(t *testing.T) {
+	tests := []struct {
+		message string
+		want    int
+	}{
+		{message: "feat: add foo (#12)", want: 12},
+		{message: "feat: add foo (#12)\n\n* add foo\n* add tests", want: 12},
+		{message: "Merge pull request #34 from thschue/foo\n\nfeat: add foo", want: 34},
+		{message: "feat: add foo\n\nSee merge request group/project!56", want: 56},
+		{message: "feat: add foo", want: 0},
+	}
+	for _, tt := range tests {
+		if got := ParsePullRequestNumber(tt.message); got != tt.want {
+			t.Errorf("ParsePullRequestNumber(%q) = %d, want %d", tt.message, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package changelog
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Release is the changelog of a single released version
+type Release struct {
+	Version   string
+	Date      time.Time
+	Changelog string
+}
+
+// RenderRelease renders the changelog of a release as a section of a CHANGELOG.md file
+func RenderRelease(release Release) string {
+	var buffer bytes.Buffer
+
+	if release.Date.IsZero() {
+		buffer.WriteString(fmt.Sprintf("## %s\n\n", release.Version))
+	} else {
+		buffer.WriteString(fmt.Sprintf("## %s (%s)\n\n", release.Version, release.Date.Format("2006-01-02")))
+	}
+
+	// Demote the headings of the changelog so they are nested below the release heading
+	for _, line := range strings.Split(strings.TrimRight(release.Changelog, "\n"), "\n") {
+		if strings.HasPrefix(line, "#") {
+			line = "#" + line
+		}
+		buffer.WriteString(line + "\n")
+	}
+
+	return buffer.String()
+}
+
+// RenderChangelogFile renders the changelogs of all releases, newest first, as the content of a CHANGELOG.md file
+func RenderChangelogFile(releases []Release) string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("# Changelog\n")
+	for i := len(releases) - 1; i >= 0; i-- {
+		buffer.WriteString("\n")
+		buffer.WriteString(RenderRelease(releases[i]))
+	}
+
+	return buffer.String()
+}
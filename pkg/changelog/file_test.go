@@ -0,0 +1,40 @@
+package changelog
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRenderChangelogFile(t *testing.T) {
+	releases := []Release{
+		{
+			Version:   "v0.1.0",
+			Date:      time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC),
+			Changelog: "## Features\n- [Initial release](https://github.com/thschue/git-releaser/commit/abc123)\n\n",
+		},
+		{
+			Version:   "v0.2.0",
+			Date:      time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+			Changelog: "## Bug Fixes\n- [Fixed a bug](https://github.com/thschue/git-releaser/commit/def456)\n\n",
+		},
+	}
+
+	expected := `# Changelog
+
+## v0.2.0 (2024-01-15)
+
+### Bug Fixes
+- [Fixed a bug](https://github.com/thschue/git-releaser/commit/def456)
+
+## v0.1.0 (2023-12-01)
+
+### Features
+- [Initial release](https://github.com/thschue/git-releaser/commit/abc123)
+`
+
+	result := RenderChangelogFile(releases)
+
+	if result != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, result)
+	}
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"github.com/git-releaser/git-releaser/pkg/config"
+	"github.com/git-releaser/git-releaser/pkg/git"
+	"github.com/git-releaser/git-releaser/pkg/versioning"
+	"github.com/spf13/viper"
+	"os"
+)
+
+// ReadConfig reads the configuration file, a missing file gives the default configuration
+func ReadConfig() config.Config {
+	conf, err := config.ReadConfig(viper.ConfigFileUsed())
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			fmt.Println(err)
+		}
+	}
+	return conf
+}
+
+// GitConfig returns the settings of the git provider from the flags of the executed command and the configuration
+func GitConfig(conf config.Config) git.Config {
+	additionalConfig := make(map[string]string)
+
+	if viper.GetString("repository") != "" {
+		additionalConfig["repository"] = viper.GetString("repository")
+	}
+
+	if viper.GetInt("project_id") != 0 {
+		additionalConfig["projectId"] = fmt.Sprintf("%d", viper.GetInt("project_id"))
+	}
+
+	return git.Config{
+		Provider:           viper.GetString("provider"),
+		AccessToken:        viper.GetString("token"),
+		UserId:             viper.GetString("user_id"),
+		ProjectUrl:         viper.GetString("project_url"),
+		ApiUrl:             viper.GetString("api_url"),
+		AdditionalConfig:   additionalConfig,
+		PropagationTargets: conf.PropagationTargets,
+		Author:             conf.Author,
+		Signing:            conf.Signing,
+		DryRun:             viper.GetBool("dry-run"),
+		ConfigUpdates:      conf.ConfigUpdates,
+		Changelog:          conf.Changelog,
+		VersionConstraint:  versioning.ReleaseLineConstraint(conf.ReleaseLines, conf.TargetBranch),
+	}
+}
+
+// NewGitClient creates the git provider from the flags of the executed command and the configuration
+func NewGitClient(conf config.Config) git.Provider {
+	return git.NewGitClient(GitConfig(conf))
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v3"
+)
+
+const ExpectAtLeastOne = "at_least_one"
+
+// Expectation is the number of versions which have to be replaced in every file matched by an extra file.
+// It is written as `expect: at_least_one` or `expect: {exactly: 2}`.
+type Expectation struct {
+	AtLeastOne bool
+	Exactly    *int
+}
+
+func (e *Expectation) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		if value.Value != ExpectAtLeastOne {
+			return fmt.Errorf("line %d: unknown expectation %q, use %s or exactly: N", value.Line, value.Value, ExpectAtLeastOne)
+		}
+		*e = Expectation{AtLeastOne: true}
+		return nil
+	case yaml.MappingNode:
+		var expect struct {
+			Exactly *int `yaml:"exactly"`
+		}
+		if err := value.Decode(&expect); err != nil {
+			return err
+		}
+		if expect.Exactly == nil || *expect.Exactly < 0 {
+			return fmt.Errorf("line %d: expectation needs a non-negative number for exactly", value.Line)
+		}
+		*e = Expectation{Exactly: expect.Exactly}
+		return nil
+	}
+
+	return fmt.Errorf("line %d: expectation must be %s or exactly: N", value.Line, ExpectAtLeastOne)
+}
+
+// IsSet reports whether an expectation has been configured
+func (e Expectation) IsSet() bool {
+	return e.AtLeastOne || e.Exactly != nil
+}
+
+// Check returns an error if the number of replacements doesn't meet the expectation
+func (e Expectation) Check(count int) error {
+	if e.Exactly != nil && count != *e.Exactly {
+		return fmt.Errorf("expected exactly %d replacement(s), found %d", *e.Exactly, count)
+	}
+	if e.AtLeastOne && count == 0 {
+		return fmt.Errorf("expected at least one replacement, found none")
+	}
+	return nil
+}
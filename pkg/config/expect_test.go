@@ -0,0 +1,44 @@
+package config
+
+import (
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+func TestExpectation(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantErr   bool
+		count     int
+		wantCheck bool
+	}{
+		{name: "unset accepts no replacements", input: `path: a.txt`, count: 0, wantCheck: true},
+		{name: "at least one with replacement", input: "path: a.txt\nexpect: at_least_one", count: 2, wantCheck: true},
+		{name: "at least one without replacement", input: "path: a.txt\nexpect: at_least_one", count: 0, wantCheck: false},
+		{name: "exactly matching", input: "path: a.txt\nexpect:\n  exactly: 2", count: 2, wantCheck: true},
+		{name: "exactly too many", input: "path: a.txt\nexpect:\n  exactly: 2", count: 3, wantCheck: false},
+		{name: "exactly zero", input: "path: a.txt\nexpect: {exactly: 0}", count: 0, wantCheck: true},
+		{name: "unknown scalar", input: "path: a.txt\nexpect: some", wantErr: true},
+		{name: "missing number", input: "path: a.txt\nexpect: {at_least: 1}", wantErr: true},
+		{name: "negative number", input: "path: a.txt\nexpect: {exactly: -1}", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var extraFile ExtraFileConfig
+			err := yaml.Unmarshal([]byte(tt.input), &extraFile)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			err = extraFile.Expect.Check(tt.count)
+			if (err == nil) != tt.wantCheck {
+				t.Errorf("Check(%d) error = %v, want success %v", tt.count, err, tt.wantCheck)
+			}
+		})
+	}
+}
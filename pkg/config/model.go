@@ -13,6 +13,14 @@ type Config struct {
 	Provider           string              `yaml:"provider"`
 	ExtraFiles         []ExtraFileConfig   `yaml:"extra_files"`
 	ConfigUpdates      []ConfigUpdate      `yaml:"config_updates"`
+	Assets             []AssetConfig       `yaml:"assets,omitempty"`
+	Checksums          ChecksumConfig      `yaml:"checksums,omitempty"`
+	Author             AuthorConfig        `yaml:"author,omitempty"`
+	Signing            SigningConfig       `yaml:"signing,omitempty"`
+	Release            ReleaseConfig       `yaml:"release,omitempty"`
+	ReleaseLines       []ReleaseLine       `yaml:"release_lines,omitempty"`
+	AutoMerge          AutoMergeConfig     `yaml:"auto_merge,omitempty"`
+	PullRequest        PullRequestConfig   `yaml:"pull_request,omitempty"`
 	UserId             string              `yaml:"user_id"`
 	AccessToken        string              `yaml:"access_token"`
 	ProjectUrl         string              `yaml:"project_url"`
@@ -21,6 +29,17 @@ type Config struct {
 	Repository         string              `yaml:"repository,omitempty"`
 	PropagationTargets []PropagationTarget `yaml:"propagation_targets"`
 	Versioning         VersioningConfig    `yaml:"versioning"`
+	Changelog          ChangelogConfig     `yaml:"changelog"`
+}
+
+// ChangelogSourcePullRequests makes the changelog use merged pull requests instead of commit messages
+const ChangelogSourcePullRequests = "pull_requests"
+
+type ChangelogConfig struct {
+	Contributors                   bool              `yaml:"contributors"`
+	HighlightFirstTimeContributors bool              `yaml:"highlight_first_time_contributors"`
+	Source                         string            `yaml:"source,omitempty"`
+	LabelSections                  map[string]string `yaml:"label_sections,omitempty"`
 }
 
 type VersioningConfig struct {
@@ -36,6 +55,7 @@ type ConfigUpdate struct {
 	SearchTag  string   `yaml:"search_tag"`
 	Repository string   `yaml:"repository"`
 	Files      []string `yaml:"files"`
+	Exclude    []string `yaml:"exclude,omitempty"`
 }
 type SimpleCommitTypes struct {
 	Patch        []string `yaml:"patch"`
@@ -51,8 +71,115 @@ type PropagationTarget struct {
 }
 
 type ExtraFileConfig struct {
-	Path  string `yaml:"path"`
+	// Path is a file, a directory or a glob pattern like deploy/**/values*.yaml
+	Path string `yaml:"path"`
+	// Exclude lists patterns of files which are skipped even if they match the path
+	Exclude []string `yaml:"exclude,omitempty"`
+	Label   string   `yaml:"label,omitempty"`
+	// Type selects a structured updater (json, yaml, toml or xml) or an ecosystem updater
+	// (helm, npm, cargo, python, go or go-module) instead of the version markers
+	Type string `yaml:"type,omitempty"`
+	// Selector is the path of the value to update in structured files, e.g. $.version or /project/version
+	Selector string `yaml:"selector,omitempty"`
+	// Regex matches the versions to update with a named group, e.g. appVersion: "(?P<version>[^"]+)"
+	Regex string `yaml:"regex,omitempty"`
+	// Replacement is the template written into the version group of the regex, e.g. v{{.Major}}.{{.Minor}}
+	Replacement string `yaml:"replacement,omitempty"`
+	// Expect fails the release if a matched file doesn't contain the expected number of versions
+	Expect Expectation `yaml:"expect,omitempty"`
+}
+
+// AssetConfig selects files which are attached to a release
+type AssetConfig struct {
+	// Path is a file or a glob pattern like dist/*.tar.gz
+	Path    string   `yaml:"path"`
+	Exclude []string `yaml:"exclude,omitempty"`
+	// Label is the display name of the asset, the file name is used by default
 	Label string `yaml:"label,omitempty"`
+	// ContentType of the asset, detected from the file extension by default
+	ContentType string `yaml:"content_type,omitempty"`
+}
+
+// ChecksumConfig creates a checksum file for the assets of a release, which can be signed
+type ChecksumConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// File is the name of the checksum file, SHA256SUMS by default
+	File string           `yaml:"file,omitempty"`
+	Sign SigningKeyConfig `yaml:"sign,omitempty"`
+}
+
+// SigningKeyConfig points to an armored OpenPGP or an OpenSSH private key
+type SigningKeyConfig struct {
+	// Key is the path of the private key file
+	Key string `yaml:"key,omitempty"`
+	// KeyEnv is the environment variable holding the private key, used instead of Key
+	KeyEnv string `yaml:"key_env,omitempty"`
+	// PassphraseEnv is the environment variable holding the passphrase of an encrypted key
+	PassphraseEnv string `yaml:"passphrase_env,omitempty"`
+}
+
+// IsSet reports whether a signing key has been configured
+func (k SigningKeyConfig) IsSet() bool {
+	return k.Key != "" || k.KeyEnv != ""
+}
+
+// ReleaseConfig configures the releases created by git-releaser
+type ReleaseConfig struct {
+	// Draft creates the releases as drafts, which are published with git-releaser release publish
+	Draft bool `yaml:"draft,omitempty"`
+	// FloatingTags moves the tags vMAJOR and vMAJOR.MINOR to every new release
+	FloatingTags bool `yaml:"floating_tags,omitempty"`
+}
+
+// ReleaseLine maps the branches matching a pattern to a range of versions, e.g. maintenance branches of
+// older minor versions
+type ReleaseLine struct {
+	// Branch is the name or glob pattern of the branches, e.g. release/*
+	Branch string `yaml:"branch"`
+	// Versions is the version constraint of the line, derived from branch names like release/1.4 if empty
+	Versions string `yaml:"versions,omitempty"`
+	// Maintenance restricts the line to patch releases
+	Maintenance bool `yaml:"maintenance,omitempty"`
+}
+
+// AutoMergeConfig merges release pull requests automatically when their checks pass, if the release
+// matches the policy
+type AutoMergeConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Bumps are the version bumps which are merged automatically: patch, minor or major. Only patch by default.
+	Bumps []string `yaml:"bumps,omitempty"`
+	// AllowBreaking merges releases with breaking changes, e.g. a minor bump of a version before 1.0.0
+	AllowBreaking bool `yaml:"allow_breaking,omitempty"`
+	// Method is the merge method, merge, squash or rebase, the default of the project is used if empty
+	Method string `yaml:"method,omitempty"`
+}
+
+// PullRequestConfig configures the reviewers, assignees, milestone and labels of release pull requests
+type PullRequestConfig struct {
+	// Reviewers are the usernames of the requested reviewers
+	Reviewers []string `yaml:"reviewers,omitempty"`
+	// TeamReviewers are the slugs of the requested teams on GitHub, e.g. my-org/maintainers
+	TeamReviewers []string `yaml:"team_reviewers,omitempty"`
+	// Assignees are the usernames of the assigned users
+	Assignees []string `yaml:"assignees,omitempty"`
+	// Milestone is the title of the milestone, a template with the fields of a replacement, e.g.
+	// {{.Original}}. The milestone is created if it doesn't exist.
+	Milestone string `yaml:"milestone,omitempty"`
+	// Labels are added to the labels of git-releaser
+	Labels []string `yaml:"labels,omitempty"`
+}
+
+// AuthorConfig is the identity of the commits and tags created by git-releaser
+type AuthorConfig struct {
+	Name  string `yaml:"name,omitempty"`
+	Email string `yaml:"email,omitempty"`
+}
+
+// SigningConfig signs the commits of the release branches with the key, and the release tags if Tags is set
+type SigningConfig struct {
+	SigningKeyConfig `yaml:",inline"`
+	// Tags creates the release tags as signed annotated tags and pushes them before the release is created
+	Tags bool `yaml:"tags,omitempty"`
 }
 
 type Versions struct {
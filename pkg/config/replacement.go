@@ -0,0 +1,60 @@
+package config
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"os"
+	"regexp"
+)
+
+// TagReplacement replaces the versions of the lines annotated with "# x-git-releaser:<search_tag>" in the
+// matching files with the value
+type TagReplacement struct {
+	SearchTag string   `yaml:"search_tag"`
+	Value     string   `yaml:"value"`
+	Files     []string `yaml:"files"`
+	Exclude   []string `yaml:"exclude,omitempty"`
+}
+
+// searchTagRegex matches the valid search tags, which can't contain whitespace or the characters of regular expressions
+var searchTagRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/-]*$`)
+
+// ValidateSearchTag returns an error if the search tag can't be used in an annotation
+func ValidateSearchTag(tag string) error {
+	if !searchTagRegex.MatchString(tag) {
+		return fmt.Errorf("invalid search tag %q, only letters, digits, '.', '_', '/' and '-' are allowed", tag)
+	}
+	return nil
+}
+
+// ReplacementSpec describes many replacements which are applied in a single branch and merge request
+type ReplacementSpec struct {
+	Branch       string           `yaml:"branch,omitempty"`
+	TargetBranch string           `yaml:"target_branch,omitempty"`
+	Replacements []TagReplacement `yaml:"replacements"`
+}
+
+func ReadReplacementSpec(filename string) (ReplacementSpec, error) {
+	var spec ReplacementSpec
+
+	file, err := os.ReadFile(filename)
+	if err != nil {
+		return spec, err
+	}
+
+	err = yaml.Unmarshal(file, &spec)
+	if err != nil {
+		return spec, err
+	}
+
+	for i, replacement := range spec.Replacements {
+		if replacement.SearchTag == "" || replacement.Value == "" || len(replacement.Files) == 0 {
+			return spec, fmt.Errorf("replacement %d needs a search_tag, a value and files", i+1)
+		}
+		if err := ValidateSearchTag(replacement.SearchTag); err != nil {
+			return spec, fmt.Errorf("replacement %d: %w", i+1, err)
+		}
+	}
+
+	return spec, nil
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadReplacementSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "multiple replacements",
+			content: `branch: release/bump-services
+replacements:
+- search_tag: service-a
+  value: 1.2.3
+  files:
+  - deploy/**/values.yaml
+- search_tag: service-b
+  value: 2.0.0
+  files:
+  - deploy/service-b
+  exclude:
+  - deploy/service-b/legacy`,
+			want: 2,
+		},
+		{
+			name: "missing files",
+			content: `replacements:
+- search_tag: service-a
+  value: 1.2.3`,
+			wantErr: true,
+		},
+		{
+			name: "invalid search tag",
+			content: `replacements:
+- search_tag: service.*
+  value: 1.2.3
+  files:
+  - deploy`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "spec.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			spec, err := ReadReplacementSpec(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadReplacementSpec() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && len(spec.Replacements) != tt.want {
+				t.Errorf("ReadReplacementSpec() = %d replacements, want %d", len(spec.Replacements), tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateSearchTag(t *testing.T) {
+	tests := []struct {
+		tag     string
+		wantErr bool
+	}{
+		{tag: "service-a"},
+		{tag: "charts/app.image_tag"},
+		{tag: "", wantErr: true},
+		{tag: "service a", wantErr: true},
+		{tag: "service.*", wantErr: true},
+		{tag: "-service", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		err := ValidateSearchTag(tt.tag)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateSearchTag(%q) error = %v", tt.tag, err)
+		}
+	}
+}
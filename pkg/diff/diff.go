@@ -0,0 +1,188 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// context is the number of unchanged lines shown around changes
+const context = 3
+
+// maxMatrixSize limits the memory of the line matching, bigger changes are shown as complete replacement
+const maxMatrixSize = 4_000_000
+
+type operation struct {
+	kind byte
+	line string
+	// the indexes of the line in the old and new content
+	oldIndex int
+	newIndex int
+}
+
+// Unified returns the changes between the old and new content of a file as unified diff, or an empty
+// string if there are no changes
+func Unified(path string, oldContent []byte, newContent []byte) string {
+	if string(oldContent) == string(newContent) {
+		return ""
+	}
+
+	operations := compare(splitLines(string(oldContent)), splitLines(string(newContent)))
+
+	var sb strings.Builder
+	sb.WriteString("--- a/" + path + "\n")
+	sb.WriteString("+++ b/" + path + "\n")
+
+	for _, hunk := range hunks(operations) {
+		first := hunk[0]
+
+		oldCount, newCount := 0, 0
+		for _, op := range hunk {
+			if op.kind != '+' {
+				oldCount++
+			}
+			if op.kind != '-' {
+				newCount++
+			}
+		}
+
+		sb.WriteString(fmt.Sprintf("@@ -%s +%s @@\n", hunkRange(first.oldIndex, oldCount), hunkRange(first.newIndex, newCount)))
+		for _, op := range hunk {
+			sb.WriteByte(op.kind)
+			sb.WriteString(op.line)
+			if !strings.HasSuffix(op.line, "\n") {
+				sb.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+// splitLines splits text into lines which keep their line break
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// compare returns the operations turning the old into the new lines based on their longest common subsequence
+func compare(a []string, b []string) []operation {
+	// Unchanged lines at the start and the end don't need to be matched
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var operations []operation
+	for i := 0; i < prefix; i++ {
+		operations = append(operations, operation{kind: ' ', line: a[i], oldIndex: i, newIndex: i})
+	}
+
+	middleA, middleB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	for _, op := range compareMiddle(middleA, middleB) {
+		op.oldIndex += prefix
+		op.newIndex += prefix
+		operations = append(operations, op)
+	}
+
+	for i := 0; i < suffix; i++ {
+		oldIndex, newIndex := len(a)-suffix+i, len(b)-suffix+i
+		operations = append(operations, operation{kind: ' ', line: a[oldIndex], oldIndex: oldIndex, newIndex: newIndex})
+	}
+
+	return operations
+}
+
+func compareMiddle(a []string, b []string) []operation {
+	var operations []operation
+
+	if len(a)*len(b) > maxMatrixSize {
+		for i, line := range a {
+			operations = append(operations, operation{kind: '-', line: line, oldIndex: i})
+		}
+		for j, line := range b {
+			operations = append(operations, operation{kind: '+', line: line, oldIndex: len(a), newIndex: j})
+		}
+		return operations
+	}
+
+	// lengths[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			operations = append(operations, operation{kind: ' ', line: a[i], oldIndex: i, newIndex: j})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lengths[i+1][j] >= lengths[i][j+1]):
+			operations = append(operations, operation{kind: '-', line: a[i], oldIndex: i, newIndex: j})
+			i++
+		default:
+			operations = append(operations, operation{kind: '+', line: b[j], oldIndex: i, newIndex: j})
+			j++
+		}
+	}
+
+	return operations
+}
+
+// hunks groups the changed operations with their surrounding context, hunks with overlapping context are merged
+func hunks(operations []operation) [][]operation {
+	var result [][]operation
+
+	start, end := -1, -1
+	for i, op := range operations {
+		if op.kind == ' ' {
+			continue
+		}
+
+		from := max(i-context, 0)
+		if start >= 0 && from > end {
+			result = append(result, operations[start:end])
+			start = -1
+		}
+		if start < 0 {
+			start = from
+		}
+		end = min(i+context+1, len(operations))
+	}
+	if start >= 0 {
+		result = append(result, operations[start:end])
+	}
+
+	return result
+}
+
+// hunkRange formats the start line and the number of lines of a hunk, the start of an empty range is the line before it
+func hunkRange(index int, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", index)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", index+1)
+	}
+	return fmt.Sprintf("%d,%d", index+1, count)
+}
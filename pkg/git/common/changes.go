@@ -0,0 +1,81 @@
+package common
+
+import (
+	"bytes"
+	"github.com/git-releaser/git-releaser/pkg/diff"
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/util"
+	"strings"
+)
+
+// fileChanges keeps the new content of files in memory until they are written, so files can be
+// updated several times and dry-runs don't modify the worktree
+type fileChanges struct {
+	fs       billy.Filesystem
+	paths    []string
+	original map[string][]byte
+	content  map[string][]byte
+}
+
+func newFileChanges(fs billy.Filesystem) *fileChanges {
+	return &fileChanges{
+		fs:       fs,
+		original: make(map[string][]byte),
+		content:  make(map[string][]byte),
+	}
+}
+
+// ReadFile returns the changed content of a file or its content in the filesystem
+func (c *fileChanges) ReadFile(path string) ([]byte, error) {
+	if content, ok := c.content[path]; ok {
+		return content, nil
+	}
+	return util.ReadFile(c.fs, path)
+}
+
+// WriteFile changes the content of a file in memory
+func (c *fileChanges) WriteFile(path string, content []byte) {
+	if _, ok := c.content[path]; !ok {
+		// New files have no original content
+		original, _ := util.ReadFile(c.fs, path)
+		c.original[path] = original
+		c.paths = append(c.paths, path)
+	}
+	c.content[path] = content
+}
+
+// Paths returns the changed files in the order they were first written
+func (c *fileChanges) Paths() []string {
+	return c.paths
+}
+
+// Changed returns the files whose content differs from the filesystem
+func (c *fileChanges) Changed() []string {
+	var paths []string
+	for _, path := range c.paths {
+		if !bytes.Equal(c.original[path], c.content[path]) {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// Diff returns the unified diff of all changed files
+func (c *fileChanges) Diff() string {
+	var sb strings.Builder
+	for _, path := range c.paths {
+		sb.WriteString(diff.Unified(path, c.original[path], c.content[path]))
+	}
+	return sb.String()
+}
+
+// Apply writes the changed files to the filesystem
+func (c *fileChanges) Apply() error {
+	for _, path := range c.paths {
+		err := util.WriteFile(c.fs, path, c.content[path], 0644)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
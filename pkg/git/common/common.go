@@ -4,19 +4,20 @@ import (
 	"errors"
 	"fmt"
 	"github.com/git-releaser/git-releaser/pkg/config"
+	"github.com/git-releaser/git-releaser/pkg/helpers"
+	"github.com/git-releaser/git-releaser/pkg/signing"
+	"github.com/git-releaser/git-releaser/pkg/updater"
 	"github.com/go-git/go-billy/v5/memfs"
 	"github.com/go-git/go-billy/v5/osfs"
 	"github.com/go-git/go-git/v5"
 	gitconfig "github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
-	"github.com/go-git/go-git/v5/plumbing/object"
 	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/go-git/go-git/v5/storage/memory"
-	"io"
 	"os"
 	"path/filepath"
 	"regexp"
-	"time"
+	"strings"
 )
 
 type GoGitRepository struct {
@@ -24,6 +25,10 @@ type GoGitRepository struct {
 	Auth          *githttp.BasicAuth
 	Repository    *git.Repository
 	Worktree      *git.Worktree
+	// Author is the identity of the commits and tags, git-releaser <no-reply@git-releaser.com> by default
+	Author config.AuthorConfig
+	// Signer signs the commits and tags if set
+	Signer signing.Signer
 }
 
 type ChangeSet struct {
@@ -32,6 +37,24 @@ type ChangeSet struct {
 }
 
 func (g *GoGitRepository) CheckoutBranch(target string) error {
+	err := g.openRepository(target)
+	if err != nil {
+		return err
+	}
+
+	err = g.Worktree.Pull(&git.PullOptions{
+		RemoteName: "origin",
+		Auth:       g.Auth,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		fmt.Println("Could not pull the latest changes")
+		return err
+	}
+	return nil
+}
+
+// openRepository opens the repository in the current directory for "plain" or clones it into memory for "temp"
+func (g *GoGitRepository) openRepository(target string) error {
 	var err error
 
 	g.Repository = &git.Repository{}
@@ -56,19 +79,7 @@ func (g *GoGitRepository) CheckoutBranch(target string) error {
 	}
 
 	g.Worktree, err = g.Repository.Worktree()
-	if err != nil {
-		return err
-	}
-
-	err = g.Worktree.Pull(&git.PullOptions{
-		RemoteName: "origin",
-		Auth:       g.Auth,
-	})
-	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
-		fmt.Println("Could not pull the latest changes")
-		return err
-	}
-	return nil
+	return err
 }
 
 func (g GoGitRepository) CommitFile(branchName string, changeset []ChangeSet) error {
@@ -111,13 +122,7 @@ func (g GoGitRepository) CommitFile(branchName string, changeset []ChangeSet) er
 	}
 
 	// Commit the changes
-	commit, err := g.Worktree.Commit("releaser: update files", &git.CommitOptions{
-		Author: &object.Signature{
-			Name:  "git-releaser",
-			Email: "no-reply@git-releaser.com",
-			When:  time.Now(),
-		},
-	})
+	commit, err := g.Worktree.Commit("releaser: update files", g.commitOptions())
 	if err != nil {
 		fmt.Println("Could not commit changes")
 		return err
@@ -148,67 +153,105 @@ func (g GoGitRepository) CommitFile(branchName string, changeset []ChangeSet) er
 	return nil
 }
 
-func (g GoGitRepository) CommitManifest(branchName string, content string, versions config.Versions, extraFiles []config.ExtraFileConfig, dryRun bool) error {
+// ManifestChanges are the manifest and extra files of a release commit, which are prepared before the
+// release branch is created
+type ManifestChanges struct {
+	repository *git.Repository
+	worktree   *git.Worktree
+	files      *fileChanges
+}
+
+// PrepareManifest updates the manifest and the versions in the extra files in memory and checks the
+// expectations of the extra files, so a failed check doesn't leave a release branch behind
+func (g GoGitRepository) PrepareManifest(content string, versions config.Versions, extraFiles []config.ExtraFileConfig, dryRun bool) (ManifestChanges, error) {
 	if g.Worktree == nil {
-		err := g.CheckoutBranch("plain")
+		var err error
+		if dryRun {
+			// A dry run only reads the files, so the worktree isn't updated by a pull either
+			err = g.openRepository("plain")
+		} else {
+			err = g.CheckoutBranch("plain")
+		}
 		if err != nil {
-			return err
+			return ManifestChanges{}, err
 		}
 	}
 
 	filePath := ".git-releaser-manifest.json"
 
-	// Create or update the file in the worktree
+	// Collect the changes in memory, so nothing is written in a dry run
+	files := newFileChanges(g.Worktree.Filesystem)
+	files.WriteFile(filePath, []byte(content))
 
-	file, err := g.Worktree.Filesystem.Create(filePath)
-	if err != nil {
-		fmt.Println("Could not create file: "+g.Worktree.Filesystem.Root(), filePath)
-		return err
+	var replacements []replacement
+	var failures []string
+	for _, extraFile := range extraFiles {
+		paths, err := helpers.Glob(g.Worktree.Filesystem, []string{extraFile.Path}, extraFile.Exclude)
+		if err != nil {
+			fmt.Println("Could not find files for pattern: " + extraFile.Path + ": " + err.Error())
+			failures = append(failures, extraFile.Path+": "+err.Error())
+			continue
+		}
+		if len(paths) == 0 {
+			fmt.Println("No files found for pattern: " + extraFile.Path)
+			if extraFile.Expect.IsSet() {
+				failures = append(failures, extraFile.Path+": no files found")
+			}
+			continue
+		}
+
+		for _, path := range paths {
+			file := extraFile
+			file.Path = path
+
+			replaced, err := replaceExtraFile(files, file, versions)
+			if err != nil {
+				fmt.Println("Could not update version in file: " + path + ": " + err.Error())
+				if extraFile.Expect.IsSet() {
+					failures = append(failures, path+": "+err.Error())
+				}
+			} else if err = extraFile.Expect.Check(countReplacements(replaced, path)); err != nil {
+				failures = append(failures, path+": "+err.Error())
+			}
+			replacements = append(replacements, replaced...)
+		}
 	}
-	defer file.Close()
+	printReplacements(replacements)
 
-	_, err = file.Write([]byte(content))
-	if err != nil {
-		fmt.Println("Could not write to file: "+g.Worktree.Filesystem.Root(), filePath)
-		return err
+	if len(failures) > 0 {
+		return ManifestChanges{}, fmt.Errorf("the versions in the extra files don't match the expectations:\n  %s", strings.Join(failures, "\n  "))
 	}
 
-	// Add the file to the worktree
-	_, err = g.Worktree.Add(filePath)
+	return ManifestChanges{repository: g.Repository, worktree: g.Worktree, files: files}, nil
+}
+
+// CommitManifest commits the prepared changes to the release branch and pushes it
+func (g GoGitRepository) CommitManifest(branchName string, changes ManifestChanges, versions config.Versions, dryRun bool) error {
+	if dryRun {
+		fmt.Println("Dry run: would commit and push the following changes:")
+		fmt.Print(changes.files.Diff())
+		return nil
+	}
+	g.Repository = changes.repository
+	g.Worktree = changes.worktree
+
+	// Write the changes to the worktree
+	err := changes.files.Apply()
 	if err != nil {
-		fmt.Println("Could not add file to git: " + filepath.Join(g.Worktree.Filesystem.Root(), filePath))
+		fmt.Println("Could not write files to: " + g.Worktree.Filesystem.Root())
 		return err
 	}
 
-	for _, extraFile := range extraFiles {
-		err = replaceVersionLines(extraFile, versions)
-		if err != nil {
-			fmt.Println("Could not update version in file: " + extraFile.Path)
-		}
-
-		err = replaceVersionBetweenTags(extraFile, versions)
-		if err != nil {
-			fmt.Println("Could not update version in file: " + extraFile.Path)
-		}
-
-		_, err = g.Worktree.Add(extraFile.Path)
+	for _, path := range changes.files.Paths() {
+		_, err = g.Worktree.Add(path)
 		if err != nil {
-			fmt.Println("Could not add file to git: " + filepath.Join(g.Worktree.Filesystem.Root(), extraFile.Path))
+			fmt.Println("Could not add file to git: " + filepath.Join(g.Worktree.Filesystem.Root(), path))
+			return err
 		}
 	}
 
-	if dryRun {
-		fmt.Println("Dry run: would commit and push changes")
-		return nil
-	}
 	// Commit the changes
-	commit, err := g.Worktree.Commit("releaser: update files for version "+versions.NextVersion.Original(), &git.CommitOptions{
-		Author: &object.Signature{
-			Name:  "git-releaser",
-			Email: "no-reply@git-releaser.com",
-			When:  time.Now(),
-		},
-	})
+	commit, err := g.Worktree.Commit("releaser: update files for version "+versions.NextVersion.Original(), g.commitOptions())
 	if err != nil {
 		fmt.Println("Could not commit changes")
 		return err
@@ -240,57 +283,176 @@ func (g GoGitRepository) CommitManifest(branchName string, content string, versi
 	return nil
 }
 
-func replaceVersionLines(extraFile config.ExtraFileConfig, versions config.Versions) error {
+// replacement records how many versions were replaced in a file by one kind of marker or updater
+type replacement struct {
+	path   string
+	marker string
+	count  int
+}
+
+const (
+	markerAnnotation = "annotation"
+	markerBlock      = "block"
+	markerRegex      = "regex"
+	markerTag        = "tag"
+)
+
+// replaceExtraFile updates the version in a single extra file, using the updater of its type or the version markers
+func replaceExtraFile(files *fileChanges, extraFile config.ExtraFileConfig, versions config.Versions) ([]replacement, error) {
+	if extraFile.Regex != "" {
+		if extraFile.Type != "" {
+			return nil, fmt.Errorf("regex and type can't be combined")
+		}
+		return replaceRegexVersion(files, extraFile, versions)
+	}
+
+	if extraFile.Type != "" {
+		return replaceStructuredVersion(files, extraFile, versions)
+	}
+
+	lines, err := replaceVersionLines(files, extraFile, versions)
+	if err != nil {
+		return nil, err
+	}
+	replacements := []replacement{{path: extraFile.Path, marker: markerAnnotation, count: lines}}
+
+	blocks, err := replaceVersionBetweenTags(files, extraFile, versions)
+	if err != nil {
+		return replacements, err
+	}
+	return append(replacements, replacement{path: extraFile.Path, marker: markerBlock, count: blocks}), nil
+}
+
+func replaceVersionLines(files *fileChanges, extraFile config.ExtraFileConfig, versions config.Versions) (int, error) {
 	// Read the contents of the file
-	content, err := os.ReadFile(extraFile.Path)
+	content, err := files.ReadFile(extraFile.Path)
 	if err != nil {
 		fmt.Println("Could not read file: " + extraFile.Path)
-		return err
+		return 0, err
 	}
 
-	// Define a regular expression to match the version string with the annotation format
-	versionRegex := regexp.MustCompile(`(?m)(.*?)(\d+\.\d+\.\d+)(.*?)# x-git-releaser-version`)
+	// Replace all occurrences of the version in annotated lines with the new version in the format of the annotation
+	modifiedContent, count, err := updater.Annotations(content, versions.NextVersion)
+	if err != nil || count == 0 {
+		return 0, err
+	}
 
-	// Replace all occurrences of the version in annotated lines with the new version
-	modifiedContent := versionRegex.ReplaceAllString(string(content), "${1}"+versions.NextVersion.String()+"${3}# x-git-releaser-version")
+	files.WriteFile(extraFile.Path, modifiedContent)
+	return count, nil
+}
 
-	// Write the modified contents back to the file
-	err = os.WriteFile(extraFile.Path, []byte(modifiedContent), 0644)
+func replaceVersionBetweenTags(files *fileChanges, extraFile config.ExtraFileConfig, versions config.Versions) (int, error) {
+	// Read the contents of the file
+	content, err := files.ReadFile(extraFile.Path)
 	if err != nil {
-		fmt.Println("Could not write file: " + extraFile.Path)
-		return err
+		fmt.Println("Could not read file: " + extraFile.Path)
+		return 0, err
 	}
 
-	return nil
+	// Replace the version string with the new version, preserving the rest of the text
+	modifiedContent, count, err := updater.Blocks(content, versions.NextVersion)
+	if err != nil || count == 0 {
+		return 0, err
+	}
+
+	files.WriteFile(extraFile.Path, modifiedContent)
+	return count, nil
 }
 
-func replaceVersionBetweenTags(extraFile config.ExtraFileConfig, versions config.Versions) error {
+// replaceRegexVersion replaces the version group of the regex of the extra file with its replacement template
+func replaceRegexVersion(files *fileChanges, extraFile config.ExtraFileConfig, versions config.Versions) ([]replacement, error) {
 	// Read the contents of the file
-	content, err := os.ReadFile(extraFile.Path)
+	content, err := files.ReadFile(extraFile.Path)
 	if err != nil {
 		fmt.Println("Could not read file: " + extraFile.Path)
-		return err
+		return nil, err
 	}
 
-	// Define a regular expression to match the version string and the rest of the text between the start and end tags
-	versionRegex := regexp.MustCompile(`(?s)(<!-- x-git-releaser-version-start -->)(.*?)(\d+\.\d+\.\d+)(.*?)(<!-- x-git-releaser-version-end -->)`)
+	modifiedContent, count, err := updater.Regex(content, extraFile.Regex, extraFile.Replacement, versions.NextVersion)
+	if err != nil {
+		return nil, err
+	}
 
-	// Replace the version string with the new version, preserving the rest of the text
-	modifiedContent := versionRegex.ReplaceAllString(string(content), "${1}${2}"+versions.NextVersion.String()+"${4}${5}")
+	if count > 0 {
+		files.WriteFile(extraFile.Path, modifiedContent)
+	}
+
+	return []replacement{{path: extraFile.Path, marker: markerRegex, count: count}}, nil
+}
 
-	// Write the modified contents back to the file
-	err = os.WriteFile(extraFile.Path, []byte(modifiedContent), 0644)
+// replaceStructuredVersion runs the updater of the file type and returns the replacements in all files it touched
+func replaceStructuredVersion(files *fileChanges, extraFile config.ExtraFileConfig, versions config.Versions) ([]replacement, error) {
+	var updates []updater.FileUpdate
+	var err error
+	if strings.EqualFold(extraFile.Type, updater.TypeGoModule) {
+		// Move the module path and its imports to the next major version
+		updates, err = updater.UpdateGoModule(os.DirFS(files.fs.Root()), extraFile.Path, files.ReadFile, versions.NextVersion.String())
+	} else {
+		// Replace the value with the new version, preserving the formatting of the files
+		updates, err = updater.Update(extraFile.Type, extraFile.Path, extraFile.Selector, files.ReadFile, versions.NextVersion.String())
+	}
 	if err != nil {
-		fmt.Println("Could not write file: " + extraFile.Path)
-		return err
+		return nil, err
 	}
 
-	return nil
+	var replacements []replacement
+	for _, update := range updates {
+		if update.Count > 0 {
+			files.WriteFile(update.Path, update.Content)
+		}
+		replacements = append(replacements, replacement{path: update.Path, marker: extraFile.Type, count: update.Count})
+	}
+
+	return replacements, nil
 }
 
-func (g GoGitRepository) ReplaceTaggedLines(filenames []string, sourceTag string, replaceTag string) ([]ChangeSet, error) {
-	var changes []ChangeSet
+// countReplacements sums up the replacements of all markers in a file
+func countReplacements(replacements []replacement, path string) int {
+	count := 0
+	for _, r := range replacements {
+		if r.path == path {
+			count += r.count
+		}
+	}
+	return count
+}
+
+// printReplacements lists the matched files with the number of replacements made by each kind of marker
+func printReplacements(replacements []replacement) {
+	if len(replacements) == 0 {
+		return
+	}
+
+	var paths []string
+	markers := make(map[string][]string)
+	for _, r := range replacements {
+		if _, ok := markers[r.path]; !ok {
+			paths = append(paths, r.path)
+			markers[r.path] = []string{}
+		}
+		if r.count > 0 {
+			markers[r.path] = append(markers[r.path], fmt.Sprintf("%s: %d", r.marker, r.count))
+		}
+	}
 
+	fmt.Println("Updated files:")
+	for _, path := range paths {
+		count := countReplacements(replacements, path)
+		if count == 0 {
+			fmt.Printf("  %s: no version found\n", path)
+			continue
+		}
+		fmt.Printf("  %s: %d replacement(s) (%s)\n", path, count, strings.Join(markers[path], ", "))
+	}
+}
+
+func (g GoGitRepository) ReplaceTaggedLines(patterns []string, excludes []string, sourceTag string, replaceTag string) ([]ChangeSet, error) {
+	return g.ReplaceTags([]config.TagReplacement{{SearchTag: sourceTag, Value: replaceTag, Files: patterns, Exclude: excludes}})
+}
+
+// ReplaceTags applies all replacements to the files of the repository and returns the changed files. A
+// file can be changed by several replacements.
+func (g GoGitRepository) ReplaceTags(replacements []config.TagReplacement) ([]ChangeSet, error) {
 	if g.Worktree == nil {
 		err := g.CheckoutBranch("temp")
 		if err != nil {
@@ -298,26 +460,49 @@ func (g GoGitRepository) ReplaceTaggedLines(filenames []string, sourceTag string
 		}
 	}
 
-	for _, filename := range filenames {
-		file, err := g.Worktree.Filesystem.Open(filename)
-		if err != nil {
-			fmt.Println("Could not open file: " + filename)
-		}
-		defer file.Close()
+	files := newFileChanges(g.Worktree.Filesystem)
 
-		content, err := io.ReadAll(file)
+	var reports []replacement
+	for _, r := range replacements {
+		filenames, err := helpers.Glob(g.Worktree.Filesystem, r.Files, r.Exclude)
 		if err != nil {
-			fmt.Println("Could not read file: " + filename)
+			return []ChangeSet{}, err
 		}
 
-		// Define a regular expression to match the version string with the annotation format
-		versionRegex := regexp.MustCompile(`(?m)(.*?)(\d+\.\d+\.\d+)(.*?)# x-git-releaser:` + sourceTag)
-
-		// Replace all occurrences of the version in annotated lines with the new version
-		modifiedContent := versionRegex.ReplaceAllString(string(content), "${1}"+replaceTag+"${3}# x-git-releaser:"+sourceTag)
+		// Define a regular expression to match the version string with the annotation format, the tag ends
+		// at a whitespace or the end of the line, so service-a doesn't match service-ab
+		versionRegex := regexp.MustCompile(`(?m)(.*?)(\d+\.\d+\.\d+)(.*?)# x-git-releaser:` + regexp.QuoteMeta(r.SearchTag) + `(\s|$)`)
+
+		for _, filename := range filenames {
+			content, err := files.ReadFile(filename)
+			if err != nil {
+				fmt.Println("Could not read file: " + filename)
+				continue
+			}
+
+			count := len(versionRegex.FindAllIndex(content, -1))
+			reports = append(reports, replacement{path: filename, marker: markerTag + " " + r.SearchTag, count: count})
+			if count == 0 {
+				continue
+			}
+
+			// Replace all occurrences of the version in annotated lines with the new version
+			modifiedContent := versionRegex.ReplaceAllString(string(content), "${1}"+escapeTemplate(r.Value)+"${3}# x-git-releaser:"+escapeTemplate(r.SearchTag)+"${4}")
+			files.WriteFile(filename, []byte(modifiedContent))
+		}
+	}
+	printReplacements(reports)
 
-		changes = append(changes, ChangeSet{fileName: filename, content: modifiedContent})
+	var changes []ChangeSet
+	for _, path := range files.Changed() {
+		content, _ := files.ReadFile(path)
+		changes = append(changes, ChangeSet{fileName: path, content: string(content)})
 	}
 
 	return changes, nil
 }
+
+// escapeTemplate escapes the $ signs of a value, so it is used literally in the template of Regexp.ReplaceAllString
+func escapeTemplate(value string) string {
+	return strings.ReplaceAll(value, "$", "$$")
+}
@@ -0,0 +1,188 @@
+package common
+
+import (
+	"github.com/Masterminds/semver"
+	"github.com/git-releaser/git-releaser/pkg/config"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReplaceExtraFile(t *testing.T) {
+	tests := []struct {
+		name        string
+		extraFile   config.ExtraFileConfig
+		content     string
+		want        string
+		annotations int
+		blocks      int
+		regexes     int
+	}{
+		{
+			name:        "annotations and blocks",
+			content:     "version: 1.0.0 # x-git-releaser-version\n<!-- x-git-releaser-version-start -->1.0.0<!-- x-git-releaser-version-end -->\n",
+			want:        "version: 1.2.0 # x-git-releaser-version\n<!-- x-git-releaser-version-start -->1.2.0<!-- x-git-releaser-version-end -->\n",
+			annotations: 1,
+			blocks:      1,
+		},
+		{
+			name:      "regex with replacement template",
+			extraFile: config.ExtraFileConfig{Regex: `appVersion: "(?P<version>[^"]+)"`, Replacement: "v{{.Major}}.{{.Minor}}"},
+			content:   "version: 1.0.0\nappVersion: \"v1.0\"\n",
+			want:      "version: 1.0.0\nappVersion: \"v1.2\"\n",
+			regexes:   1,
+		},
+		{
+			name:    "misspelled marker",
+			content: "version: 1.0.0 # x-git-relaser-version\n",
+			want:    "version: 1.0.0 # x-git-relaser-version\n",
+		},
+	}
+
+	versions := config.Versions{NextVersion: *semver.MustParse("1.2.0")}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := memfs.New()
+			path := "file.txt"
+			err := util.WriteFile(fs, path, []byte(tt.content), 0644)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			extraFile := tt.extraFile
+			extraFile.Path = path
+
+			files := newFileChanges(fs)
+			replaced, err := replaceExtraFile(files, extraFile, versions)
+			if err != nil {
+				t.Fatalf("replaceExtraFile() error = %v", err)
+			}
+
+			counts := make(map[string]int)
+			for _, r := range replaced {
+				counts[r.marker] += r.count
+			}
+			if counts[markerAnnotation] != tt.annotations || counts[markerBlock] != tt.blocks || counts[markerRegex] != tt.regexes {
+				t.Errorf("replaceExtraFile() counts = %v, want %d annotation(s), %d block(s) and %d regex match(es)", counts, tt.annotations, tt.blocks, tt.regexes)
+			}
+			if got := countReplacements(replaced, path); got != tt.annotations+tt.blocks+tt.regexes {
+				t.Errorf("countReplacements() = %d, want %d", got, tt.annotations+tt.blocks+tt.regexes)
+			}
+
+			content, err := util.ReadFile(fs, path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(content) != tt.content {
+				t.Errorf("replaceExtraFile() changed the file before applying the changes")
+			}
+
+			err = files.Apply()
+			if err != nil {
+				t.Fatal(err)
+			}
+			content, err = util.ReadFile(fs, path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(content) != tt.want {
+				t.Errorf("replaceExtraFile() content = %q, want %q", content, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplaceTags(t *testing.T) {
+	repository, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree, err := repository.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"deploy/a/values.yaml": "a: 1.0.0 # x-git-releaser:service-a\nb: 1.0.0 # x-git-releaser:service-b\n",
+		"deploy/b/values.yaml": "b: 1.0.0 # x-git-releaser:service-b\n",
+		"deploy/c/values.yaml": "c: 1.0.0 # x-git-releaser:service-c\n",
+		// The search tags are neither prefixes nor regular expressions
+		"deploy/d/values.yaml": "d: 1.0.0 # x-git-releaser:service-ab\ne: 1.0.0 # x-git-releaser:service-e1\n",
+	}
+	for name, content := range files {
+		if err := util.WriteFile(worktree.Filesystem, name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	g := GoGitRepository{Repository: repository, Worktree: worktree}
+	changes, err := g.ReplaceTags([]config.TagReplacement{
+		{SearchTag: "service-a", Value: "1.2.0", Files: []string{"deploy/**/values.yaml"}},
+		{SearchTag: "service-b", Value: "2.0.0", Files: []string{"deploy"}, Exclude: []string{"deploy/b"}},
+		{SearchTag: "service.e1", Value: "3.0.0", Files: []string{"deploy/d/values.yaml"}},
+	})
+	if err != nil {
+		t.Fatalf("ReplaceTags() error = %v", err)
+	}
+
+	want := []ChangeSet{
+		{fileName: "deploy/a/values.yaml", content: "a: 1.2.0 # x-git-releaser:service-a\nb: 2.0.0 # x-git-releaser:service-b\n"},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("ReplaceTags() = %v, want %v", changes, want)
+	}
+}
+
+func TestCommitManifestDryRun(t *testing.T) {
+	dir := t.TempDir()
+	repository, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree, err := repository.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitFile(t, worktree, ".git-releaser-manifest.json", `{"version": "v1.0.0"}`, "initial commit")
+
+	// The remote can't be reached, so a pull would fail
+	_, err = repository.CreateRemote(&gitconfig.RemoteConfig{Name: "origin", URLs: []string{filepath.Join(dir, "missing")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	g := GoGitRepository{}
+	versions := config.Versions{NextVersion: *semver.MustParse("v1.1.0")}
+	changes, err := g.PrepareManifest(`{"version": "v1.1.0"}`, versions, nil, true)
+	if err != nil {
+		t.Fatalf("PrepareManifest() error = %v", err)
+	}
+	err = g.CommitManifest("release-v1.1.0", changes, versions, true)
+	if err != nil {
+		t.Fatalf("CommitManifest() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, ".git-releaser-manifest.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != `{"version": "v1.0.0"}` {
+		t.Errorf("manifest changed in a dry run: %s", content)
+	}
+}
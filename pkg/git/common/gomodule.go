@@ -0,0 +1,48 @@
+package common
+
+import (
+	"fmt"
+	"github.com/Masterminds/semver"
+	"github.com/git-releaser/git-releaser/pkg/config"
+	"github.com/git-releaser/git-releaser/pkg/helpers"
+	"github.com/git-releaser/git-releaser/pkg/updater"
+	"github.com/go-git/go-billy/v5"
+	"path"
+	"strings"
+)
+
+// GoModuleTags returns the tags of the nested Go modules among the extra files for a release, e.g.
+// tools/v1.2.0 for the module in the tools directory. A module at the root of the repository is
+// tagged by the release itself.
+func GoModuleTags(fs billy.Filesystem, extraFiles []config.ExtraFileConfig, version semver.Version) ([]string, error) {
+	var tags []string
+	seen := make(map[string]struct{})
+
+	for _, extraFile := range extraFiles {
+		if !strings.EqualFold(extraFile.Type, updater.TypeGoModule) {
+			continue
+		}
+
+		paths, err := helpers.Glob(fs, []string{extraFile.Path}, extraFile.Exclude)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range paths {
+			dir := path.Dir(p)
+			if dir == "." {
+				continue
+			}
+
+			// Go expects module versions with a v prefix, regardless of the prefix of the release
+			tag := fmt.Sprintf("%s/v%s", dir, version.String())
+			if _, ok := seen[tag]; ok {
+				continue
+			}
+			seen[tag] = struct{}{}
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags, nil
+}
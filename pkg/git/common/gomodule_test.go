@@ -0,0 +1,36 @@
+package common
+
+import (
+	"github.com/Masterminds/semver"
+	"github.com/git-releaser/git-releaser/pkg/config"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	"reflect"
+	"testing"
+)
+
+func TestGoModuleTags(t *testing.T) {
+	fs := memfs.New()
+	for _, name := range []string{"go.mod", "tools/go.mod", "api/go.mod", "examples/go.mod"} {
+		err := util.WriteFile(fs, name, []byte("module example.com/app\n"), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	extraFiles := []config.ExtraFileConfig{
+		{Path: "**/go.mod", Exclude: []string{"examples"}, Type: "go-module"},
+		{Path: "api/go.mod", Type: "go-module"},
+		{Path: "version.go", Type: "go"},
+	}
+
+	tags, err := GoModuleTags(fs, extraFiles, *semver.MustParse("v2.1.0"))
+	if err != nil {
+		t.Fatalf("GoModuleTags() error = %v", err)
+	}
+
+	want := []string{"api/v2.1.0", "tools/v2.1.0"}
+	if !reflect.DeepEqual(tags, want) {
+		t.Errorf("GoModuleTags() = %v, want %v", tags, want)
+	}
+}
@@ -1,10 +1,17 @@
 package common
 
 import (
+	"fmt"
+	"github.com/Masterminds/semver"
+	"github.com/git-releaser/git-releaser/pkg/changelog"
+	"github.com/git-releaser/git-releaser/pkg/config"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"log"
+	"sort"
 	"strings"
+	"time"
 )
 
 func GetGitHistory(path string, tag string) ([]object.Commit, error) {
@@ -52,3 +59,202 @@ func GetGitHistory(path string, tag string) ([]object.Commit, error) {
 
 	return commits, err
 }
+
+// ConvertCommits converts commits of the local history into changelog commits, keeping the
+// author and the Co-authored-by trailers of every commit. The patch-id and the pull request
+// merged by a merge commit of the list are recorded to identify each change.
+func ConvertCommits(commits []object.Commit) []changelog.Commit {
+	pullRequests := getMergedPullRequests(commits)
+
+	var result []changelog.Commit
+	for _, c := range commits {
+		result = append(result, changelog.Commit{
+			ID:          c.Hash.String(),
+			Message:     c.Message,
+			Timestamp:   c.Author.When.String(),
+			AuthorName:  c.Author.Name,
+			AuthorEmail: c.Author.Email,
+			CoAuthors:   changelog.ParseCoAuthors(c.Message),
+			PatchID:     PatchID(&c),
+			PullRequest: pullRequests[c.Hash],
+		})
+	}
+	return result
+}
+
+// GetContributorsBefore returns the keys of all authors and co-authors of the commits
+// reachable from the given tag. If the tag does not exist, no contributors are returned.
+func GetContributorsBefore(path string, tag string) (map[string]struct{}, error) {
+	contributors := make(map[string]struct{})
+
+	r, err := git.PlainOpen(path)
+	if err != nil {
+		return contributors, err
+	}
+
+	ref, err := r.Tag(tag)
+	if err != nil {
+		return contributors, nil
+	}
+
+	hash, err := r.ResolveRevision(plumbing.Revision(ref.Name().String()))
+	if err != nil {
+		return contributors, err
+	}
+
+	iter, err := r.Log(&git.LogOptions{From: *hash})
+	if err != nil {
+		return contributors, err
+	}
+
+	err = iter.ForEach(func(c *object.Commit) error {
+		authors := append([]changelog.Author{{Name: c.Author.Name, Email: c.Author.Email}}, changelog.ParseCoAuthors(c.Message)...)
+		for _, author := range authors {
+			for _, key := range author.Keys() {
+				contributors[key] = struct{}{}
+			}
+		}
+		return nil
+	})
+
+	return contributors, err
+}
+
+// NewChangelogOptions creates the changelog options for the given configuration. The contributors
+// known before sinceRelease are read from the local history if first-time contributors are highlighted.
+func NewChangelogOptions(cfg config.ChangelogConfig, sinceRelease string) changelog.Options {
+	opts := changelog.Options{
+		Contributors:                   cfg.Contributors,
+		HighlightFirstTimeContributors: cfg.HighlightFirstTimeContributors,
+	}
+	if opts.Contributors && opts.HighlightFirstTimeContributors {
+		knownContributors, err := GetContributorsBefore("", sinceRelease)
+		if err != nil {
+			fmt.Println("Could not get previous contributors: " + err.Error())
+		}
+		opts.KnownContributors = knownContributors
+	}
+	return opts
+}
+
+// VersionTag is a tag of the local repository which refers to a semantic version
+type VersionTag struct {
+	Name    string
+	Version *semver.Version
+	Date    time.Time
+}
+
+// GetCommitsBetween returns the commits reachable from the revision to, but not from the revision
+// from, newest first. An empty from starts at the beginning of the history, an empty to means HEAD.
+func GetCommitsBetween(path string, from string, to string) ([]object.Commit, error) {
+	r, err := git.PlainOpen(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if to == "" {
+		to = "HEAD"
+	}
+	toHash, err := resolveRevision(r, to)
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := make(map[plumbing.Hash]struct{})
+	if from != "" {
+		fromHash, err := resolveRevision(r, from)
+		if err != nil {
+			return nil, err
+		}
+
+		iter, err := r.Log(&git.LogOptions{From: fromHash})
+		if err != nil {
+			return nil, err
+		}
+		err = iter.ForEach(func(c *object.Commit) error {
+			excluded[c.Hash] = struct{}{}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	iter, err := r.Log(&git.LogOptions{From: toHash})
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []object.Commit
+	err = iter.ForEach(func(c *object.Commit) error {
+		if _, exists := excluded[c.Hash]; exists {
+			return nil
+		}
+		commits = append(commits, *c)
+		return nil
+	})
+
+	return commits, err
+}
+
+// ListVersionTags returns all tags of the local repository which are semantic versions with the
+// given prefix, sorted from the lowest to the highest version
+func ListVersionTags(path string, prefix string) ([]VersionTag, error) {
+	r, err := git.PlainOpen(path)
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := r.Tags()
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []VersionTag
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().Short()
+		if !strings.HasPrefix(name, prefix) {
+			return nil
+		}
+
+		version, err := semver.NewVersion(strings.TrimPrefix(name, prefix))
+		if err != nil {
+			return nil // Ignore tags which are no versions
+		}
+
+		hash, err := resolveRevision(r, name)
+		if err != nil {
+			return err
+		}
+		commit, err := r.CommitObject(hash)
+		if err != nil {
+			return err
+		}
+
+		tags = append(tags, VersionTag{Name: name, Version: version, Date: commit.Committer.When})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Version.LessThan(tags[j].Version)
+	})
+
+	return tags, nil
+}
+
+// resolveRevision resolves a tag, branch or commit to the hash of a commit, preferring tags
+func resolveRevision(r *git.Repository, revision string) (plumbing.Hash, error) {
+	hash, err := r.ResolveRevision(plumbing.Revision("refs/tags/" + revision))
+	if err == nil {
+		return *hash, nil
+	}
+
+	hash, err = r.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("could not resolve revision %s: %w", revision, err)
+	}
+	return *hash, nil
+}
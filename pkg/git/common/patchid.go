@@ -0,0 +1,150 @@
+package common
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"github.com/git-releaser/git-releaser/pkg/changelog"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"strings"
+	"unicode"
+)
+
+// PatchID returns an identifier of the change introduced by a commit, similar to "git patch-id".
+// It only depends on the paths and the added and removed lines (ignoring whitespace), so a
+// cherry-pick of a commit has the same patch-id as the original. Merge and root commits have none, neither
+// have empty commits, as with "git patch-id".
+func PatchID(c *object.Commit) string {
+	if c.NumParents() != 1 {
+		return ""
+	}
+
+	parent, err := c.Parent(0)
+	if err != nil {
+		return ""
+	}
+
+	patch, err := parent.Patch(c)
+	if err != nil || len(patch.FilePatches()) == 0 {
+		return ""
+	}
+
+	hash := sha1.New()
+	for _, filePatch := range patch.FilePatches() {
+		from, to := filePatch.Files()
+		if from != nil {
+			hash.Write([]byte("a/" + from.Path() + "\n"))
+		}
+		if to != nil {
+			hash.Write([]byte("b/" + to.Path() + "\n"))
+		}
+
+		for _, chunk := range filePatch.Chunks() {
+			var prefix string
+			switch chunk.Type() {
+			case diff.Add:
+				prefix = "+"
+			case diff.Delete:
+				prefix = "-"
+			default:
+				continue
+			}
+
+			for _, line := range strings.Split(chunk.Content(), "\n") {
+				hash.Write([]byte(prefix + stripWhitespace(line) + "\n"))
+			}
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// AddPatchIDs sets the patch-id of commits received from a provider which are part of the local repository
+func AddPatchIDs(path string, commits []changelog.Commit) []changelog.Commit {
+	r, err := git.PlainOpen(path)
+	if err != nil {
+		return commits
+	}
+
+	for i, commit := range commits {
+		if commit.PatchID != "" {
+			continue
+		}
+
+		c, err := r.CommitObject(plumbing.NewHash(commit.ID))
+		if err != nil {
+			continue
+		}
+		commits[i].PatchID = PatchID(c)
+	}
+	return commits
+}
+
+// getMergedPullRequests returns the pull request number for the commits which were merged by a
+// merge commit of a pull request. Only commits of the given list are considered.
+func getMergedPullRequests(commits []object.Commit) map[plumbing.Hash]int {
+	result := make(map[plumbing.Hash]int)
+
+	inList := make(map[plumbing.Hash]*object.Commit)
+	for i := range commits {
+		inList[commits[i].Hash] = &commits[i]
+	}
+
+	for i := range commits {
+		c := &commits[i]
+		if c.NumParents() != 2 {
+			continue
+		}
+
+		number := changelog.ParsePullRequestNumber(c.Message)
+		if number == 0 {
+			continue
+		}
+
+		// The pull request consists of the commits reachable from the merged branch but not from the base
+		base := reachableInList(c.ParentHashes[0], inList)
+		for hash := range reachableInList(c.ParentHashes[1], inList) {
+			if _, exists := base[hash]; !exists {
+				if _, exists := result[hash]; !exists {
+					result[hash] = number
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// reachableInList returns the commits of the list which are reachable from start without leaving the list
+func reachableInList(start plumbing.Hash, inList map[plumbing.Hash]*object.Commit) map[plumbing.Hash]struct{} {
+	reachable := make(map[plumbing.Hash]struct{})
+	queue := []plumbing.Hash{start}
+
+	for len(queue) > 0 {
+		hash := queue[0]
+		queue = queue[1:]
+
+		c, exists := inList[hash]
+		if !exists {
+			continue
+		}
+		if _, visited := reachable[hash]; visited {
+			continue
+		}
+		reachable[hash] = struct{}{}
+		queue = append(queue, c.ParentHashes...)
+	}
+
+	return reachable
+}
+
+func stripWhitespace(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
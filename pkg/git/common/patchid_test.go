@@ -0,0 +1,162 @@
+package common
+
+import (
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"testing"
+	"time"
+)
+
+func commitFile(t *testing.T, w *git.Worktree, name string, content string, message string) plumbing.Hash {
+	t.Helper()
+
+	file, err := w.Filesystem.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = file.Write([]byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	_, err = w.Add(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash, err := w.Commit(message, &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hash
+}
+
+func TestPatchIDOfCherryPick(t *testing.T) {
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := r.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	base := commitFile(t, w, "README.md", "hello\n", "chore: initial commit")
+	original := commitFile(t, w, "main.go", "package main\n", "feat: add main")
+
+	// Apply the same change on a branch with a different history and message
+	err = w.Checkout(&git.CheckoutOptions{Hash: base, Branch: "refs/heads/release", Create: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitFile(t, w, "CHANGELOG.md", "# Changelog\n", "docs: add changelog")
+	cherryPick := commitFile(t, w, "main.go", "package  main\n", "feat: add main (backport)")
+	other := commitFile(t, w, "other.go", "package main\n", "feat: add other")
+
+	patchID := func(hash plumbing.Hash) string {
+		c, err := r.CommitObject(hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return PatchID(c)
+	}
+
+	if patchID(original) == "" {
+		t.Fatal("Expected a patch-id for the original commit")
+	}
+	if patchID(original) != patchID(cherryPick) {
+		t.Errorf("Expected the cherry-pick to have the same patch-id as the original")
+	}
+	if patchID(original) == patchID(other) {
+		t.Errorf("Expected a different change to have a different patch-id")
+	}
+	if patchID(base) != "" {
+		t.Errorf("Expected no patch-id for the root commit")
+	}
+}
+
+func TestPatchIDOfEmptyCommits(t *testing.T) {
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := r.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitFile(t, w, "README.md", "hello\n", "chore: initial commit")
+
+	for _, message := range []string{"fix: first empty commit", "fix: second empty commit"} {
+		hash, err := w.Commit(message, &git.CommitOptions{
+			Author:            &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+			AllowEmptyCommits: true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		c, err := r.CommitObject(hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id := PatchID(c); id != "" {
+			t.Errorf("Expected no patch-id for the empty commit %q, got %s", message, id)
+		}
+	}
+}
+
+func TestConvertCommitsAddsMergedPullRequests(t *testing.T) {
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := r.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	base := commitFile(t, w, "README.md", "hello\n", "chore: initial commit")
+	mainCommit := commitFile(t, w, "main.go", "package main\n", "fix: add main")
+
+	err = w.Checkout(&git.CheckoutOptions{Hash: base, Branch: "refs/heads/feature", Create: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	featureCommit := commitFile(t, w, "feature.go", "package main\n", "feat: add feature")
+
+	merge, err := w.Commit("Merge pull request #7 from thschue/feature\n\nfeat: add feature", &git.CommitOptions{
+		Author:  &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+		Parents: []plumbing.Hash{mainCommit, featureCommit},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var commits []object.Commit
+	for _, hash := range []plumbing.Hash{merge, featureCommit, mainCommit} {
+		c, err := r.CommitObject(hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		commits = append(commits, *c)
+	}
+
+	expected := map[string]int{
+		merge.String():         0,
+		featureCommit.String(): 7,
+		mainCommit.String():    0,
+	}
+
+	for _, commit := range ConvertCommits(commits) {
+		if commit.PullRequest != expected[commit.ID] {
+			t.Errorf("Expected pull request %d for commit %s, got %d", expected[commit.ID], commit.ID, commit.PullRequest)
+		}
+	}
+}
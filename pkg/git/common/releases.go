@@ -0,0 +1,33 @@
+package common
+
+import (
+	"github.com/Masterminds/semver"
+)
+
+// HighestVersion returns the highest version of the release tags which meets the constraint, or 0.0.0 if
+// there is none. Tags which are no versions are ignored, an empty constraint accepts all versions.
+func HighestVersion(tags []string, constraint string) (semver.Version, error) {
+	var constraints *semver.Constraints
+	if constraint != "" {
+		var err error
+		constraints, err = semver.NewConstraint(constraint)
+		if err != nil {
+			return semver.Version{}, err
+		}
+	}
+
+	highest := semver.MustParse("0.0.0")
+	for _, tag := range tags {
+		version, err := semver.NewVersion(tag)
+		if err != nil {
+			continue // Ignore invalid versions
+		}
+		if constraints != nil && !constraints.Check(version) {
+			continue
+		}
+		if version.GreaterThan(highest) {
+			highest = version
+		}
+	}
+	return *highest, nil
+}
@@ -0,0 +1,33 @@
+package common
+
+import (
+	"testing"
+)
+
+func TestHighestVersion(t *testing.T) {
+	tags := []string{"v1.3.0", "v1.4.2", "latest", "v2.0.0", "v2.1.0-rc.1", "v1.4.10"}
+
+	tests := []struct {
+		constraint string
+		want       string
+	}{
+		{constraint: "", want: "v2.1.0-rc.1"},
+		{constraint: "1.4.x", want: "v1.4.10"},
+		{constraint: "<1.4.0", want: "v1.3.0"},
+		{constraint: "3.x", want: "0.0.0"},
+	}
+
+	for _, tt := range tests {
+		got, err := HighestVersion(tags, tt.constraint)
+		if err != nil {
+			t.Fatalf("HighestVersion(%q) error = %v", tt.constraint, err)
+		}
+		if got.Original() != tt.want {
+			t.Errorf("HighestVersion(%q) = %s, want %s", tt.constraint, got.Original(), tt.want)
+		}
+	}
+
+	if _, err := HighestVersion(tags, "not a constraint"); err == nil {
+		t.Errorf("HighestVersion() with an invalid constraint succeeded")
+	}
+}
@@ -0,0 +1,156 @@
+package common
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/git-releaser/git-releaser/pkg/signing"
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"strings"
+	"time"
+)
+
+const (
+	defaultAuthorName  = "git-releaser"
+	defaultAuthorEmail = "no-reply@git-releaser.com"
+)
+
+// signature returns the configured identity of git-releaser for a commit or tag created now
+func (g GoGitRepository) signature() *object.Signature {
+	signature := &object.Signature{
+		Name:  g.Author.Name,
+		Email: g.Author.Email,
+		When:  time.Now(),
+	}
+	if signature.Name == "" {
+		signature.Name = defaultAuthorName
+	}
+	if signature.Email == "" {
+		signature.Email = defaultAuthorEmail
+	}
+	return signature
+}
+
+// commitOptions returns the options for the commits of git-releaser, which are signed if a signer is configured
+func (g GoGitRepository) commitOptions() *git.CommitOptions {
+	options := &git.CommitOptions{
+		Author:    g.signature(),
+		Committer: g.signature(),
+	}
+	if g.Signer != nil {
+		options.Signer = g.Signer
+	}
+	return options
+}
+
+// CreateSignedTag creates an annotated tag signed with the configured key for the commit of ref, which is
+// a branch or tag of the remote, and pushes it to the remote
+func (g GoGitRepository) CreateSignedTag(tag string, ref string, message string) error {
+	if g.Signer == nil {
+		return errors.New("signed tags need a signing key")
+	}
+
+	if g.Repository == nil {
+		err := g.CheckoutBranch("plain")
+		if err != nil {
+			return err
+		}
+	}
+
+	err := g.Repository.Fetch(&git.FetchOptions{
+		RemoteName: "origin",
+		RefSpecs: []gitconfig.RefSpec{
+			"+refs/heads/*:refs/remotes/origin/*",
+			"+refs/tags/*:refs/tags/*",
+		},
+		Auth: g.Auth,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		fmt.Println("Could not fetch the remote")
+		return err
+	}
+
+	target, err := g.Repository.ResolveRevision(plumbing.Revision("refs/remotes/origin/" + ref))
+	if err != nil {
+		target, err = g.Repository.ResolveRevision(plumbing.Revision(ref))
+		if err != nil {
+			return fmt.Errorf("could not find %s: %w", ref, err)
+		}
+	}
+
+	hash, err := newSignedTag(g.Repository, tag, *target, message, g.signature(), g.Signer)
+	if err != nil {
+		return err
+	}
+
+	refName := plumbing.NewTagReferenceName(tag)
+	err = g.Repository.Storer.SetReference(plumbing.NewHashReference(refName, hash))
+	if err != nil {
+		return err
+	}
+
+	err = g.Repository.Push(&git.PushOptions{
+		RemoteName: "origin",
+		RefSpecs: []gitconfig.RefSpec{
+			gitconfig.RefSpec(fmt.Sprintf("%s:%s", refName, refName)),
+		},
+		Auth: g.Auth,
+	})
+	if err != nil {
+		fmt.Println("Could not push the tag")
+		return err
+	}
+
+	fmt.Println("Signed tag " + tag + " created successfully.")
+	return nil
+}
+
+// newSignedTag stores an annotated tag object for the commit target, signed by signer. The tag object is
+// built by hand, because go-git can only sign tags with OpenPGP keys.
+func newSignedTag(repository *git.Repository, name string, target plumbing.Hash, message string, tagger *object.Signature, signer signing.Signer) (plumbing.Hash, error) {
+	// The signature follows the message, which has to end with a newline to keep them apart
+	if !strings.HasSuffix(message, "\n") {
+		message += "\n"
+	}
+
+	tag := &object.Tag{
+		Name:       name,
+		Tagger:     *tagger,
+		Message:    message,
+		TargetType: plumbing.CommitObject,
+		Target:     target,
+	}
+
+	unsigned := repository.Storer.NewEncodedObject()
+	err := tag.EncodeWithoutSignature(unsigned)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	reader, err := unsigned.Reader()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	defer reader.Close()
+
+	var content bytes.Buffer
+	_, err = content.ReadFrom(reader)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	signature, err := signer.Sign(&content)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	tag.PGPSignature = string(signature)
+
+	encoded := repository.Storer.NewEncodedObject()
+	err = tag.Encode(encoded)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return repository.Storer.SetEncodedObject(encoded)
+}
@@ -0,0 +1,216 @@
+package common
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/pem"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/git-releaser/git-releaser/pkg/config"
+	"github.com/git-releaser/git-releaser/pkg/signing"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"golang.org/x/crypto/ssh"
+	"strings"
+	"testing"
+)
+
+func TestSignedCommitAndTag(t *testing.T) {
+	entity, err := openpgp.NewEntity("Release Bot", "", "release-bot@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var publicKey bytes.Buffer
+	writer, err := armor.Encode(&publicKey, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := entity.Serialize(writer); err != nil {
+		t.Fatal(err)
+	}
+	writer.Close()
+
+	repository, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree, err := repository.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := util.WriteFile(worktree.Filesystem, "file.txt", []byte("content\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := worktree.Add("file.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	g := GoGitRepository{
+		Repository: repository,
+		Worktree:   worktree,
+		Author:     config.AuthorConfig{Name: "Release Bot", Email: "release-bot@example.com"},
+		Signer:     &signing.OpenPGPSigner{Entity: entity},
+	}
+
+	hash, err := worktree.Commit("releaser: update files", g.commitOptions())
+	if err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	commit, err := repository.CommitObject(hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if commit.Author.Name != "Release Bot" || commit.Committer.Email != "release-bot@example.com" {
+		t.Errorf("commit identity = %v / %v, want the configured author", commit.Author, commit.Committer)
+	}
+	if _, err := commit.Verify(publicKey.String()); err != nil {
+		t.Errorf("commit.Verify() error = %v", err)
+	}
+
+	tagHash, err := newSignedTag(repository, "v1.0.0", hash, "Release v1.0.0", g.signature(), g.Signer)
+	if err != nil {
+		t.Fatalf("newSignedTag() error = %v", err)
+	}
+	tag, err := repository.TagObject(tagHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tag.Name != "v1.0.0" || tag.Target != hash || tag.Tagger.Name != "Release Bot" {
+		t.Errorf("tag = %v, want v1.0.0 for %s by Release Bot", tag, hash)
+	}
+	if _, err := tag.Verify(publicKey.String()); err != nil {
+		t.Errorf("tag.Verify() error = %v", err)
+	}
+}
+
+func TestSSHSignedTag(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := ssh.MarshalPrivateKey(privateKey, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := signing.NewSigner(pem.EncodeToMemory(block), nil, signing.NamespaceGit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repository, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree, err := repository.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := util.WriteFile(worktree.Filesystem, "file.txt", []byte("content\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := worktree.Add("file.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	g := GoGitRepository{Repository: repository, Worktree: worktree, Signer: signer}
+	hash, err := worktree.Commit("releaser: update files", g.commitOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tagHash, err := newSignedTag(repository, "v1.0.0", hash, "Release v1.0.0", g.signature(), g.Signer)
+	if err != nil {
+		t.Fatalf("newSignedTag() error = %v", err)
+	}
+	tag, err := repository.TagObject(tagHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tag.Message != "Release v1.0.0\n" || !strings.HasPrefix(tag.PGPSignature, "-----BEGIN SSH SIGNATURE-----") {
+		t.Fatalf("tag = %q with signature %q, want the message followed by an SSH signature", tag.Message, tag.PGPSignature)
+	}
+
+	unsigned := &plumbing.MemoryObject{}
+	if err := tag.EncodeWithoutSignature(unsigned); err != nil {
+		t.Fatal(err)
+	}
+	reader, err := unsigned.Reader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var content bytes.Buffer
+	if _, err := content.ReadFrom(reader); err != nil {
+		t.Fatal(err)
+	}
+
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifySSHSignature(t, tag.PGPSignature, content.Bytes(), sshPublicKey)
+}
+
+// verifySSHSignature verifies an armored SSH signature of message in the git namespace like "ssh-keygen -Y verify"
+func verifySSHSignature(t *testing.T, armored string, message []byte, publicKey ssh.PublicKey) {
+	t.Helper()
+
+	encoded := strings.TrimSpace(armored)
+	encoded = strings.TrimPrefix(encoded, "-----BEGIN SSH SIGNATURE-----")
+	encoded = strings.TrimSuffix(encoded, "-----END SSH SIGNATURE-----")
+	blob, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(encoded, "\n", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sig struct {
+		Magic         [6]byte
+		Version       uint32
+		PublicKey     string
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     string
+	}
+	if err := ssh.Unmarshal(blob, &sig); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal([]byte(sig.PublicKey), publicKey.Marshal()) {
+		t.Fatal("signature was made with another key")
+	}
+	var signature ssh.Signature
+	if err := ssh.Unmarshal([]byte(sig.Signature), &signature); err != nil {
+		t.Fatal(err)
+	}
+
+	hash := sha512.Sum512(message)
+	signedData := ssh.Marshal(struct {
+		Magic         [6]byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          string
+	}{sig.Magic, sig.Namespace, "", sig.HashAlgorithm, string(hash[:])})
+
+	if sig.Namespace != signing.NamespaceGit {
+		t.Errorf("signature namespace = %q, want %q", sig.Namespace, signing.NamespaceGit)
+	}
+	if err := publicKey.Verify(signedData, &signature); err != nil {
+		t.Errorf("Verify() error = %v", err)
+	}
+}
+
+func TestDefaultSignature(t *testing.T) {
+	signature := GoGitRepository{}.signature()
+	if signature.Name != "git-releaser" || signature.Email != "no-reply@git-releaser.com" {
+		t.Errorf("signature() = %v, want git-releaser <no-reply@git-releaser.com>", signature)
+	}
+	if options := (GoGitRepository{}).commitOptions(); options.Signer != nil {
+		t.Errorf("commitOptions() without a signer = %v, want no signer", options.Signer)
+	}
+}
@@ -0,0 +1,30 @@
+package git
+
+import (
+	"fmt"
+	"github.com/Masterminds/semver"
+	"github.com/git-releaser/git-releaser/pkg/versioning"
+	"strings"
+)
+
+// MoveFloatingTags moves the floating tags vMAJOR and vMAJOR.MINOR of version to the commit of ref. Tags
+// which would move backwards because a higher release exists are left alone.
+func MoveFloatingTags(g Provider, version semver.Version, ref string) error {
+	tags, err := g.ListTags()
+	if err != nil {
+		return err
+	}
+
+	move, skipped := versioning.FloatingTags(version, tags)
+	if len(skipped) > 0 {
+		fmt.Println("Not moving " + strings.Join(skipped, ", ") + ", a higher release exists")
+	}
+
+	for _, tag := range move {
+		err = g.MoveTag(tag, ref)
+		if err != nil {
+			return fmt.Errorf("could not move tag %s: %w", tag, err)
+		}
+	}
+	return nil
+}
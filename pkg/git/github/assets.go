@@ -0,0 +1,118 @@
+package github
+
+import (
+	"fmt"
+	"github.com/git-releaser/git-releaser/pkg/assets"
+	"github.com/google/go-github/v33/github"
+	"os"
+)
+
+// UploadReleaseAssets attaches the assets to the release with the given tag. Existing assets with the
+// same name are replaced, the new asset is uploaded under a temporary name first, so the release keeps
+// the existing asset if the upload fails.
+func (g Client) UploadReleaseAssets(tag string, releaseAssets []assets.Asset) error {
+	owner, repo := parseOwnerRepoFromURL(g.ProjectURL)
+
+	if g.DryRun {
+		for _, asset := range releaseAssets {
+			fmt.Printf("Dry run: would upload %s as %s (%s) to release %s\n", asset.Path, asset.Name, asset.ContentType, tag)
+		}
+		return nil
+	}
+
+	release, err := g.getReleaseByTag(owner, repo, tag)
+	if err != nil {
+		return err
+	}
+
+	existing, err := g.listReleaseAssets(owner, repo, release.GetID())
+	if err != nil {
+		return err
+	}
+
+	for _, asset := range releaseAssets {
+		err = g.replaceReleaseAsset(owner, repo, release.GetID(), asset, existing)
+		if err != nil {
+			return err
+		}
+		fmt.Println("Asset " + asset.Name + " uploaded successfully.")
+	}
+
+	return nil
+}
+
+// replaceReleaseAsset uploads an asset. An existing asset with the same name is only deleted after its
+// replacement has been uploaded under a temporary name, which is renamed afterwards.
+func (g Client) replaceReleaseAsset(owner string, repo string, releaseID int64, asset assets.Asset, existing map[string]int64) error {
+	id, exists := existing[asset.Name]
+	if !exists {
+		_, err := g.uploadReleaseAsset(owner, repo, releaseID, asset, asset.Name)
+		if err != nil {
+			return fmt.Errorf("could not upload asset %s: %w", asset.Name, err)
+		}
+		return nil
+	}
+
+	// A temporary asset may be left over from an upload which failed to rename it
+	temporaryName := asset.Name + ".upload"
+	if temporaryID, ok := existing[temporaryName]; ok {
+		_, err := g.GHClient.Repositories.DeleteReleaseAsset(g.Context, owner, repo, temporaryID)
+		if err != nil {
+			return fmt.Errorf("could not delete asset %s: %w", temporaryName, err)
+		}
+	}
+
+	uploaded, err := g.uploadReleaseAsset(owner, repo, releaseID, asset, temporaryName)
+	if err != nil {
+		return fmt.Errorf("could not upload asset %s: %w", asset.Name, err)
+	}
+
+	_, err = g.GHClient.Repositories.DeleteReleaseAsset(g.Context, owner, repo, id)
+	if err != nil {
+		return fmt.Errorf("could not replace asset %s: %w", asset.Name, err)
+	}
+
+	_, _, err = g.GHClient.Repositories.EditReleaseAsset(g.Context, owner, repo, uploaded.GetID(), &github.ReleaseAsset{
+		Name:  github.String(asset.Name),
+		Label: github.String(asset.Label),
+	})
+	if err != nil {
+		return fmt.Errorf("could not rename asset %s to %s: %w", temporaryName, asset.Name, err)
+	}
+	return nil
+}
+
+// listReleaseAssets returns the IDs of the assets of a release by name
+func (g Client) listReleaseAssets(owner string, repo string, releaseID int64) (map[string]int64, error) {
+	existing := make(map[string]int64)
+
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		releaseAssets, resp, err := g.GHClient.Repositories.ListReleaseAssets(g.Context, owner, repo, releaseID, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, asset := range releaseAssets {
+			existing[asset.GetName()] = asset.GetID()
+		}
+		if resp.NextPage == 0 {
+			return existing, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func (g Client) uploadReleaseAsset(owner string, repo string, releaseID int64, asset assets.Asset, name string) (*github.ReleaseAsset, error) {
+	file, err := os.Open(asset.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	uploaded, _, err := g.GHClient.Repositories.UploadReleaseAsset(g.Context, owner, repo, releaseID, &github.UploadOptions{
+		Name:      name,
+		Label:     asset.Label,
+		MediaType: asset.ContentType,
+	}, file)
+	return uploaded, err
+}
@@ -0,0 +1,105 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/git-releaser/git-releaser/pkg/assets"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestUploadReleaseAssets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.zip")
+	if err := os.WriteFile(path, []byte("app"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		existing   string
+		failUpload bool
+		want       []string
+		wantErr    bool
+	}{
+		{
+			name: "new asset",
+			want: []string{"upload app.zip"},
+		},
+		{
+			name:     "existing asset",
+			existing: `[{"id": 10, "name": "app.zip"}, {"id": 11, "name": "other.zip"}]`,
+			want:     []string{"upload app.zip.upload", "delete 10", "rename 20 app.zip"},
+		},
+		{
+			name:     "leftover temporary asset",
+			existing: `[{"id": 10, "name": "app.zip"}, {"id": 12, "name": "app.zip.upload"}]`,
+			want:     []string{"delete 12", "upload app.zip.upload", "delete 10", "rename 20 app.zip"},
+		},
+		{
+			name:       "failed upload keeps the existing asset",
+			existing:   `[{"id": 10, "name": "app.zip"}]`,
+			failUpload: true,
+			want:       []string{"upload app.zip.upload"},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/repos/owner/repo/releases/tags/v1.0.0", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"id": 1, "tag_name": "v1.0.0"}`)
+			})
+			mux.HandleFunc("/repos/owner/repo/releases/1/assets", func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodGet:
+					if tt.existing == "" {
+						fmt.Fprint(w, `[]`)
+						return
+					}
+					fmt.Fprint(w, tt.existing)
+				case http.MethodPost:
+					name := r.URL.Query().Get("name")
+					calls = append(calls, "upload "+name)
+					if tt.failUpload {
+						w.WriteHeader(http.StatusInternalServerError)
+						return
+					}
+					fmt.Fprintf(w, `{"id": 20, "name": %q}`, name)
+				}
+			})
+			mux.HandleFunc("/repos/owner/repo/releases/assets/", func(w http.ResponseWriter, r *http.Request) {
+				id := filepath.Base(r.URL.Path)
+				switch r.Method {
+				case http.MethodDelete:
+					calls = append(calls, "delete "+id)
+					w.WriteHeader(http.StatusNoContent)
+				case http.MethodPatch:
+					var body struct {
+						Name string `json:"name"`
+					}
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+						t.Error(err)
+						return
+					}
+					calls = append(calls, "rename "+id+" "+body.Name)
+					fmt.Fprintf(w, `{"id": %s, "name": %q}`, id, body.Name)
+				}
+			})
+			g := newTestClient(t, mux)
+
+			err := g.UploadReleaseAssets("v1.0.0", []assets.Asset{{Name: "app.zip", Path: path, ContentType: "application/zip"}})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UploadReleaseAssets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(calls, tt.want) {
+				t.Errorf("UploadReleaseAssets() calls = %q, want %q", calls, tt.want)
+			}
+		})
+	}
+}
@@ -11,16 +11,33 @@ import (
 	"github.com/google/go-github/v33/github"
 )
 
-func (g Client) CommitManifest(branchName string, content string, versions releaserconfig.Versions, extraFiles []releaserconfig.ExtraFileConfig) error {
-	err := g.GoGitConfig.CommitManifest(branchName, content, versions, extraFiles, g.DryRun)
+func (g Client) PrepareManifest(content string, versions releaserconfig.Versions, extraFiles []releaserconfig.ExtraFileConfig) (common.ManifestChanges, error) {
+	return g.GoGitConfig.PrepareManifest(content, versions, extraFiles, g.DryRun)
+}
+
+func (g Client) CommitManifest(branchName string, changes common.ManifestChanges, versions releaserconfig.Versions) error {
+	err := g.GoGitConfig.CommitManifest(branchName, changes, versions, g.DryRun)
 	return err
 }
 
 func (g Client) CommitFile(branchName string, changeset []common.ChangeSet) error {
+	if g.DryRun {
+		fmt.Printf("Dry run: would commit and push %d changed file(s) to %s\n", len(changeset), branchName)
+		return nil
+	}
 	err := g.GoGitConfig.CommitFile(branchName, changeset)
 	return err
 }
 
+// CreateSignedTag creates a signed annotated tag for the commit of ref locally and pushes it
+func (g Client) CreateSignedTag(tag string, ref string, message string) error {
+	if g.DryRun {
+		fmt.Printf("Dry run: would create and push signed tag %s at %s\n", tag, ref)
+		return nil
+	}
+	return g.GoGitConfig.CreateSignedTag(tag, ref, message)
+}
+
 func (g Client) GetCommitsSinceRelease(sinceRelease string) ([]changelog.Commit, error) {
 	var org string
 	var repo string
@@ -50,9 +67,13 @@ func (g Client) GetCommitsSinceRelease(sinceRelease string) ([]changelog.Commit,
 	for _, ghCommit := range ghCommits {
 		date := ghCommit.Commit.Author.Date
 		commit := changelog.Commit{
-			ID:        *ghCommit.SHA,
-			Message:   *ghCommit.Commit.Message,
-			Timestamp: date.String(),
+			ID:          *ghCommit.SHA,
+			Message:     *ghCommit.Commit.Message,
+			Timestamp:   date.String(),
+			AuthorName:  ghCommit.GetCommit().GetAuthor().GetName(),
+			AuthorEmail: ghCommit.GetCommit().GetAuthor().GetEmail(),
+			Username:    ghCommit.GetAuthor().GetLogin(),
+			CoAuthors:   changelog.ParseCoAuthors(*ghCommit.Commit.Message),
 		}
 		commits = append(commits, commit)
 	}
@@ -77,3 +98,29 @@ func (g Client) getTagCommitSHA(owner string, repo string, tagName string) (stri
 
 	return "", nil, fmt.Errorf("tag not found")
 }
+
+// GenerateChangelog creates the changelog for all commits since the given release
+func (g Client) GenerateChangelog(sinceRelease string) (string, error) {
+	commits, err := g.GetCommitsSinceRelease(sinceRelease)
+	if err != nil {
+		return "", err
+	}
+
+	return g.ChangelogFromCommits(commits, sinceRelease), nil
+}
+
+// ChangelogFromCommits creates the changelog for the given commits of the release following sinceRelease
+func (g Client) ChangelogFromCommits(commits []changelog.Commit, sinceRelease string) string {
+	commits = common.AddPatchIDs("", commits)
+
+	var conventionalCommits []changelog.ConventionalCommit
+	if g.Changelog.Source == releaserconfig.ChangelogSourcePullRequests {
+		pullRequests, remaining := g.getPullRequestsForCommits(commits)
+		conventionalCommits = append(changelog.ParsePullRequests(pullRequests, g.Changelog.LabelSections), changelog.ParseCommits(remaining)...)
+	} else {
+		conventionalCommits = changelog.ParseCommits(commits)
+	}
+
+	opts := common.NewChangelogOptions(g.Changelog, sinceRelease)
+	return changelog.GenerateChangelogWithOptions(conventionalCommits, g.ProjectURL, opts)
+}
@@ -18,12 +18,20 @@ type Client struct {
 	GHClient           *github.Client
 	PropagationTargets []config.PropagationTarget
 	ConfigUpdates      []config.ConfigUpdate
+	Changelog          config.ChangelogConfig
+	PullRequest        config.PullRequestConfig
+	Draft              bool
+	VersionConstraint  string
 	DryRun             bool
 	GoGitConfig        common.GoGitRepository
 }
 
-func (g Client) ReplaceTaggedLines(filenames []string, sourceTag string, replaceTag string) ([]common.ChangeSet, error) {
-	return g.GoGitConfig.ReplaceTaggedLines(filenames, sourceTag, replaceTag)
+func (g Client) ReplaceTaggedLines(patterns []string, excludes []string, sourceTag string, replaceTag string) ([]common.ChangeSet, error) {
+	return g.GoGitConfig.ReplaceTaggedLines(patterns, excludes, sourceTag, replaceTag)
+}
+
+func (g Client) ReplaceTags(replacements []config.TagReplacement) ([]common.ChangeSet, error) {
+	return g.GoGitConfig.ReplaceTags(replacements)
 }
 
 func NewClient(client Client) Client {
@@ -0,0 +1,34 @@
+package github
+
+import (
+	"context"
+	"github.com/google/go-github/v33/github"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// newTestClient returns a client for the repository owner/repo whose API requests are served by handler
+func newTestClient(t *testing.T, handler http.Handler) Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	serverURL, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := github.NewClient(nil)
+	client.BaseURL = serverURL
+	client.UploadURL = serverURL
+
+	return Client{
+		Context:    context.Background(),
+		ProjectURL: "https://github.com/owner/repo",
+		Repository: "owner/repo",
+		GHClient:   client,
+	}
+}
@@ -6,7 +6,10 @@ import (
 	"github.com/git-releaser/git-releaser/pkg/config"
 	"github.com/git-releaser/git-releaser/pkg/naming"
 	"github.com/google/go-github/v33/github"
+	"net/http"
+	"strconv"
 	"strings"
+	"time"
 )
 
 func (g Client) CheckCreateReleasePullRequest(source string, target string, versions config.Versions) error {
@@ -26,9 +29,10 @@ func (g Client) createPullRequest(source string, target string, versions config.
 		return err
 	}
 
-	commits, _ := g.GetCommitsSinceRelease(versions.CurrentVersion.Original())
-	conventionalCommits := changelog.ParseCommits(commits)
-	cl := changelog.GenerateChangelog(conventionalCommits, g.ProjectURL)
+	cl, err := g.GenerateChangelog(versions.CurrentVersion.Original())
+	if err != nil {
+		fmt.Println("Could not generate changelog: " + err.Error())
+	}
 
 	title := naming.GeneratePrTitle(versions.NextVersion.Original())
 	description := naming.CreatePrDescription(versions.NextVersion.Original(), cl, g.PropagationTargets, g.ConfigUpdates)
@@ -57,6 +61,7 @@ func (g Client) createPullRequest(source string, target string, versions config.
 		if err != nil {
 			return err
 		}
+		g.configurePullRequest(existingPrNumber, versions)
 		fmt.Println("Pull request updated successfully.")
 	} else {
 		// If the pull request doesn't exist, create a new one
@@ -69,7 +74,7 @@ func (g Client) createPullRequest(source string, target string, versions config.
 			return nil
 		}
 
-		_, response, err := g.GHClient.PullRequests.Create(g.Context, owner, repo, newPR)
+		pr, response, err := g.GHClient.PullRequests.Create(g.Context, owner, repo, newPR)
 		if err != nil {
 			if response.StatusCode == 403 {
 				fmt.Println("Could not create pull request: " + err.Error())
@@ -77,15 +82,211 @@ func (g Client) createPullRequest(source string, target string, versions config.
 			}
 			return err
 		}
+		g.configurePullRequest(pr.GetNumber(), versions)
 		fmt.Println("Pull request created successfully.")
 	}
 
 	return nil
 }
 
-func (g Client) CheckCreateFileMergeRequest(source string, target string) error {
+func (g Client) CheckCreateFileMergeRequest(source string, target string, title string, description string) error {
+	owner, repo := parseOwnerRepoFromURL(g.ProjectURL)
+
+	fmt.Println("Checking if a pull request for the file update already exists")
+	pullRequests, _, err := g.GHClient.PullRequests.List(g.Context, owner, repo, &github.PullRequestListOptions{
+		State: "open",
+		Head:  owner + ":" + source,
+		Base:  target,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(pullRequests) > 0 {
+		if g.DryRun {
+			fmt.Println("Pull request already exists, would update it")
+			return nil
+		}
+
+		_, _, err = g.GHClient.PullRequests.Edit(g.Context, owner, repo, pullRequests[0].GetNumber(), &github.PullRequest{
+			Title: github.String(title),
+			Body:  github.String(description),
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Println("Pull request updated successfully.")
+		return nil
+	}
+
+	if g.DryRun {
+		fmt.Println("Dry run: pull request would be created with the following details:")
+		fmt.Println("Title: " + title)
+		fmt.Println("Description: " + description)
+		fmt.Println("Source branch: " + source)
+		fmt.Println("Target branch: " + target)
+		return nil
+	}
+
+	pr, _, err := g.GHClient.PullRequests.Create(g.Context, owner, repo, &github.NewPullRequest{
+		Title: github.String(title),
+		Head:  github.String(source),
+		Base:  github.String(target),
+		Body:  github.String(description),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, _, err = g.GHClient.Issues.AddLabelsToIssue(g.Context, owner, repo, pr.GetNumber(), []string{"release-updates"})
+	if err != nil {
+		fmt.Println("Could not label the pull request: " + err.Error())
+	}
+
+	fmt.Println("Pull request created successfully.")
+	return nil
+}
+
+// configurePullRequest adds the labels, assignees, reviewers and milestone to a release pull request.
+// Failures are reported, but don't fail the release pull request.
+func (g Client) configurePullRequest(number int, versions config.Versions) {
+	owner, repo := parseOwnerRepoFromURL(g.ProjectURL)
+	settings := g.PullRequest
+
+	err := g.SetPullRequestLabels(number, append([]string{naming.LabelPending}, settings.Labels...), nil)
+	if err != nil {
+		fmt.Println("Could not label the pull request: " + err.Error())
+	}
+
+	if len(settings.Assignees) > 0 {
+		_, _, err = g.GHClient.Issues.AddAssignees(g.Context, owner, repo, number, settings.Assignees)
+		if err != nil {
+			fmt.Println("Could not assign the pull request: " + err.Error())
+		}
+	}
+
+	if len(settings.Reviewers) > 0 || len(settings.TeamReviewers) > 0 {
+		var teams []string
+		for _, team := range settings.TeamReviewers {
+			teams = append(teams, team[strings.LastIndex(team, "/")+1:])
+		}
+
+		_, _, err = g.GHClient.PullRequests.RequestReviewers(g.Context, owner, repo, number, github.ReviewersRequest{
+			Reviewers:     settings.Reviewers,
+			TeamReviewers: teams,
+		})
+		if err != nil {
+			fmt.Println("Could not request reviewers: " + err.Error())
+		}
+	}
+
+	if settings.Milestone != "" {
+		title, err := naming.CreateMilestoneTitle(settings.Milestone, versions.NextVersion)
+		if err != nil {
+			fmt.Println("Could not render the milestone: " + err.Error())
+			return
+		}
+
+		milestone, err := g.getOrCreateMilestone(owner, repo, title)
+		if err != nil {
+			fmt.Println("Could not create milestone " + title + ": " + err.Error())
+			return
+		}
+
+		_, _, err = g.GHClient.Issues.Edit(g.Context, owner, repo, number, &github.IssueRequest{Milestone: milestone.Number})
+		if err != nil {
+			fmt.Println("Could not set the milestone: " + err.Error())
+		}
+	}
+}
+
+// getOrCreateMilestone returns the milestone with the given title, which is created if it doesn't exist
+func (g Client) getOrCreateMilestone(owner string, repo string, title string) (*github.Milestone, error) {
+	opt := &github.MilestoneListOptions{State: "all", ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		milestones, resp, err := g.GHClient.Issues.ListMilestones(g.Context, owner, repo, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, milestone := range milestones {
+			if milestone.GetTitle() == title {
+				return milestone, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	milestone, _, err := g.GHClient.Issues.CreateMilestone(g.Context, owner, repo, &github.Milestone{Title: github.String(title)})
+	if err != nil {
+		return nil, err
+	}
+	fmt.Println("Milestone " + title + " created.")
+	return milestone, nil
+}
+
+// GetMergedPullRequest returns the merged pull request from source into target, or nil if there is none
+func (g Client) GetMergedPullRequest(source string, target string) (*changelog.PullRequest, error) {
+	owner, repo := parseOwnerRepoFromURL(g.ProjectURL)
+
+	pullRequests, _, err := g.GHClient.PullRequests.List(g.Context, owner, repo, &github.PullRequestListOptions{
+		State: "closed",
+		Head:  owner + ":" + source,
+		Base:  target,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pr := range pullRequests {
+		if pr.MergedAt == nil {
+			continue
+		}
+
+		var labels []string
+		for _, label := range pr.Labels {
+			labels = append(labels, label.GetName())
+		}
+		return &changelog.PullRequest{
+			Number: pr.GetNumber(),
+			Title:  pr.GetTitle(),
+			Body:   pr.GetBody(),
+			URL:    pr.GetHTMLURL(),
+			Labels: labels,
+			Author: changelog.Author{Username: pr.GetUser().GetLogin()},
+		}, nil
+	}
+	return nil, nil
+}
+
+// SetPullRequestLabels adds and removes labels of a pull request, missing labels are not removed
+func (g Client) SetPullRequestLabels(number int, add []string, remove []string) error {
+	owner, repo := parseOwnerRepoFromURL(g.ProjectURL)
+
+	if g.DryRun {
+		fmt.Printf("Dry run: would add the labels %v to pull request #%d and remove %v\n", add, number, remove)
+		return nil
+	}
+
+	if len(add) > 0 {
+		_, _, err := g.GHClient.Issues.AddLabelsToIssue(g.Context, owner, repo, number, add)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, label := range remove {
+		resp, err := g.GHClient.Issues.RemoveLabelForIssue(g.Context, owner, repo, number, label)
+		if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+			return err
+		}
+	}
 	return nil
 }
+
 func (g Client) getExistingPullRequestNumber(source, target string) (int, error) {
 	owner, repo := strings.Split(g.Repository, "/")[0], strings.Split(g.Repository, "/")[1]
 
@@ -107,3 +308,210 @@ func (g Client) getExistingPullRequestNumber(source, target string) (int, error)
 
 	return 0, nil // No existing pull request found
 }
+
+// getPullRequestsForCommits resolves the merged pull requests the given commits belong to. The pull requests
+// merged since the oldest commit are listed once, a commit belongs to one if it is its merge commit or one of
+// its commits, which keep their message and author date when they are rebased.
+// Commits which are not part of a merged pull request are returned separately.
+func (g Client) getPullRequestsForCommits(commits []changelog.Commit) ([]changelog.PullRequest, []changelog.Commit) {
+	owner, repo := parseOwnerRepoFromURL(g.ProjectURL)
+	if owner == "" || repo == "" {
+		fmt.Println("github: no repository to get pull requests from, using the commits")
+		return nil, commits
+	}
+
+	merged, err := g.listMergedPullRequests(owner, repo, oldestCommitDate(commits))
+	if err != nil {
+		fmt.Println("github: could not list merged pull requests: " + err.Error())
+		return nil, commits
+	}
+
+	byCommit := make(map[string]*github.PullRequest)
+	for _, pr := range merged {
+		byCommit[pr.GetMergeCommitSHA()] = pr
+	}
+
+	var matched []*github.PullRequest
+	seen := make(map[int]struct{})
+	for _, commit := range commits {
+		pr, ok := byCommit[commit.ID]
+		if !ok {
+			continue
+		}
+		if _, exists := seen[pr.GetNumber()]; exists {
+			continue
+		}
+		seen[pr.GetNumber()] = struct{}{}
+		matched = append(matched, pr)
+
+		prCommits, _, err := g.GHClient.PullRequests.ListCommits(g.Context, owner, repo, pr.GetNumber(), &github.ListOptions{PerPage: 100})
+		if err != nil {
+			fmt.Println("github: could not get the commits of pull request " + strconv.Itoa(pr.GetNumber()))
+			continue
+		}
+		for _, prCommit := range prCommits {
+			byCommit[prCommit.GetSHA()] = pr
+			byCommit[commitKey(prCommit.GetCommit().GetMessage(), prCommit.GetCommit().GetAuthor().GetDate().String())] = pr
+		}
+	}
+
+	var pullRequests []changelog.PullRequest
+	var remaining []changelog.Commit
+	included := make(map[int]struct{})
+	for _, commit := range commits {
+		pr, ok := byCommit[commit.ID]
+		if !ok {
+			pr, ok = byCommit[commitKey(commit.Message, commit.Timestamp)]
+		}
+		if !ok {
+			remaining = append(remaining, commit)
+			continue
+		}
+		if _, exists := included[pr.GetNumber()]; exists {
+			continue
+		}
+		included[pr.GetNumber()] = struct{}{}
+
+		var labels []string
+		for _, label := range pr.Labels {
+			labels = append(labels, label.GetName())
+		}
+
+		pullRequests = append(pullRequests, changelog.PullRequest{
+			Number: pr.GetNumber(),
+			Title:  pr.GetTitle(),
+			Body:   pr.GetBody(),
+			URL:    pr.GetHTMLURL(),
+			Labels: labels,
+			Author: changelog.Author{Username: pr.GetUser().GetLogin()},
+		})
+	}
+
+	return pullRequests, remaining
+}
+
+// listMergedPullRequests returns the pull requests which have been merged since the given time, all merged
+// pull requests if it is zero
+func (g Client) listMergedPullRequests(owner string, repo string, since time.Time) ([]*github.PullRequest, error) {
+	var merged []*github.PullRequest
+	opts := &github.PullRequestListOptions{
+		State:       "closed",
+		Sort:        "updated",
+		Direction:   "desc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for {
+		prs, resp, err := g.GHClient.PullRequests.List(g.Context, owner, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pr := range prs {
+			// A pull request is updated when it is merged, the older ones have been merged before
+			if pr.GetUpdatedAt().Before(since) {
+				return merged, nil
+			}
+			if pr.MergedAt != nil && !pr.GetMergedAt().Before(since) {
+				merged = append(merged, pr)
+			}
+		}
+
+		if resp.NextPage == 0 {
+			return merged, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// oldestCommitDate returns the earliest author date of the commits, or the zero time if it is unknown
+func oldestCommitDate(commits []changelog.Commit) time.Time {
+	var oldest time.Time
+	for _, commit := range commits {
+		date, err := time.Parse("2006-01-02 15:04:05 -0700 MST", commit.Timestamp)
+		if err != nil {
+			return time.Time{}
+		}
+		if oldest.IsZero() || date.Before(oldest) {
+			oldest = date
+		}
+	}
+	return oldest
+}
+
+// commitKey identifies a commit by its message and author date, which are kept when it is rebased
+func commitKey(message string, date string) string {
+	return message + "\x00" + date
+}
+
+// graphQLURL returns the URL of the GraphQL API. On GitHub Enterprise it is /api/graphql, which isn't
+// below the REST API at /api/v3.
+func (g Client) graphQLURL() string {
+	u := *g.GHClient.BaseURL
+	if strings.HasSuffix(u.Path, "/api/v3/") {
+		u.Path = strings.TrimSuffix(u.Path, "v3/") + "graphql"
+	} else {
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/graphql"
+	}
+	return u.String()
+}
+
+// EnableAutoMerge enables auto-merge for the open pull request from source into target, so it is merged
+// when its required checks pass. Auto-merge has to be allowed in the repository settings.
+func (g Client) EnableAutoMerge(source string, target string, method string) error {
+	owner, repo := parseOwnerRepoFromURL(g.ProjectURL)
+
+	number, err := g.getExistingPullRequestNumber(source, target)
+	if err != nil {
+		return err
+	}
+	if number == 0 {
+		if g.DryRun {
+			fmt.Println("Dry run: would enable auto-merge for the pull request from " + source)
+			return nil
+		}
+		return fmt.Errorf("there is no open pull request from %s into %s", source, target)
+	}
+
+	if g.DryRun {
+		fmt.Printf("Dry run: would enable auto-merge for pull request #%d\n", number)
+		return nil
+	}
+
+	pr, _, err := g.GHClient.PullRequests.Get(g.Context, owner, repo, number)
+	if err != nil {
+		return err
+	}
+
+	// Auto-merge is only available with the GraphQL API
+	query := struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables"`
+	}{
+		Query:     `mutation($id: ID!, $method: PullRequestMergeMethod) { enablePullRequestAutoMerge(input: {pullRequestId: $id, mergeMethod: $method}) { clientMutationId } }`,
+		Variables: map[string]interface{}{"id": pr.GetNodeID()},
+	}
+	if method != "" {
+		query.Variables["method"] = strings.ToUpper(method)
+	}
+
+	req, err := g.GHClient.NewRequest(http.MethodPost, g.graphQLURL(), query)
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	_, err = g.GHClient.Do(g.Context, req, &result)
+	if err != nil {
+		return err
+	}
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("could not enable auto-merge: %s", result.Errors[0].Message)
+	}
+
+	fmt.Printf("Auto-merge enabled for pull request #%d.\n", number)
+	return nil
+}
@@ -0,0 +1,111 @@
+package github
+
+import (
+	"fmt"
+	"github.com/git-releaser/git-releaser/pkg/changelog"
+	"github.com/google/go-github/v33/github"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGraphQLURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		want    string
+	}{
+		{name: "github.com", baseURL: "https://api.github.com/", want: "https://api.github.com/graphql"},
+		{name: "enterprise", baseURL: "https://github.example.com/api/v3/", want: "https://github.example.com/api/graphql"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseURL, err := url.Parse(tt.baseURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			client := github.NewClient(nil)
+			client.BaseURL = baseURL
+
+			if got := (Client{GHClient: client}).graphQLURL(); got != tt.want {
+				t.Errorf("graphQLURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetPullRequestsForCommits(t *testing.T) {
+	date := func(s string) string {
+		d, _ := time.Parse(time.RFC3339, s)
+		return d.String()
+	}
+	commits := []changelog.Commit{
+		{ID: "r3b", Message: "feat: three b", Timestamp: date("2024-01-05T10:00:00Z")},
+		{ID: "r3a", Message: "feat: three a", Timestamp: date("2024-01-04T10:00:00Z")},
+		{ID: "m2", Message: "Merge pull request #2", Timestamp: date("2024-01-03T12:00:00Z")},
+		{ID: "c2", Message: "fix: two", Timestamp: date("2024-01-03T10:00:00Z")},
+		{ID: "s1", Message: "feat: one (#1)", Timestamp: date("2024-01-02T10:00:00Z")},
+		{ID: "d4", Message: "chore: direct push", Timestamp: date("2024-01-01T10:00:00Z")},
+	}
+
+	requests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/owner/repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		// Pull request 5 was merged before the oldest commit, the listing stops there
+		fmt.Fprint(w, `[
+			{"number": 3, "title": "feat: three", "merge_commit_sha": "r3b", "merged_at": "2024-01-05T10:00:00Z", "updated_at": "2024-01-05T10:00:00Z"},
+			{"number": 6, "title": "closed", "updated_at": "2024-01-04T11:00:00Z"},
+			{"number": 2, "title": "fix: two", "merge_commit_sha": "m2", "merged_at": "2024-01-03T12:00:00Z", "updated_at": "2024-01-03T12:00:00Z"},
+			{"number": 1, "title": "feat: one", "merge_commit_sha": "s1", "merged_at": "2024-01-02T10:00:00Z", "updated_at": "2024-01-02T10:00:00Z", "labels": [{"name": "enhancement"}]},
+			{"number": 5, "title": "feat: old", "merge_commit_sha": "o5", "merged_at": "2023-12-01T10:00:00Z", "updated_at": "2023-12-01T10:00:00Z"}
+		]`)
+		w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/pulls?page=2>; rel="next"`)
+	})
+	mux.HandleFunc("/repos/owner/repo/pulls/3/commits", func(w http.ResponseWriter, r *http.Request) {
+		// The commits were rebased, so only their message and author date match
+		fmt.Fprint(w, `[
+			{"sha": "o3a", "commit": {"message": "feat: three a", "author": {"date": "2024-01-04T10:00:00Z"}}},
+			{"sha": "o3b", "commit": {"message": "feat: three b", "author": {"date": "2024-01-05T10:00:00Z"}}}
+		]`)
+	})
+	mux.HandleFunc("/repos/owner/repo/pulls/2/commits", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"sha": "c2", "commit": {"message": "fix: two", "author": {"date": "2024-01-03T10:00:00Z"}}}]`)
+	})
+	mux.HandleFunc("/repos/owner/repo/pulls/1/commits", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"sha": "o1", "commit": {"message": "feat: one", "author": {"date": "2024-01-01T09:00:00Z"}}}]`)
+	})
+
+	g := newTestClient(t, mux)
+	pullRequests, remaining := g.getPullRequestsForCommits(commits)
+
+	var numbers []int
+	for _, pr := range pullRequests {
+		numbers = append(numbers, pr.Number)
+	}
+	if !reflect.DeepEqual(numbers, []int{3, 2, 1}) {
+		t.Errorf("pull requests = %v, want [3 2 1]", numbers)
+	}
+	if !reflect.DeepEqual(pullRequests[2].Labels, []string{"enhancement"}) {
+		t.Errorf("labels of #1 = %v, want [enhancement]", pullRequests[2].Labels)
+	}
+	if len(remaining) != 1 || remaining[0].ID != "d4" {
+		t.Errorf("remaining commits = %v, want d4", remaining)
+	}
+	if requests != 1 {
+		t.Errorf("listed pull requests %d times, want 1", requests)
+	}
+}
+
+func TestGetPullRequestsForCommitsWithoutRepository(t *testing.T) {
+	commits := []changelog.Commit{{ID: "a", Message: "feat: a"}}
+
+	g := Client{}
+	pullRequests, remaining := g.getPullRequestsForCommits(commits)
+	if len(pullRequests) != 0 || !reflect.DeepEqual(remaining, commits) {
+		t.Errorf("getPullRequestsForCommits() = %v, %v, want the commits", pullRequests, remaining)
+	}
+}
@@ -3,11 +3,11 @@ package github
 import (
 	"fmt"
 	"github.com/Masterminds/semver"
-	"github.com/git-releaser/git-releaser/pkg/changelog"
 	"github.com/git-releaser/git-releaser/pkg/config"
+	"github.com/git-releaser/git-releaser/pkg/git/common"
 	"github.com/git-releaser/git-releaser/pkg/naming"
 	"github.com/google/go-github/v33/github"
-	"sort"
+	"net/http"
 	"strings"
 )
 
@@ -16,9 +16,10 @@ func (g Client) CreateRelease(baseBranch string, version config.Versions, descri
 	if err != nil {
 		fmt.Println("github: could not get highest release")
 	}
-	commits, _ := g.GetCommitsSinceRelease(highestRelease.Original())
-	conventionalCommits := changelog.ParseCommits(commits)
-	cl := changelog.GenerateChangelog(conventionalCommits, g.ProjectURL)
+	cl, err := g.GenerateChangelog(highestRelease.Original())
+	if err != nil {
+		fmt.Println("Could not generate changelog: " + err.Error())
+	}
 
 	if description == "" {
 		description = naming.CreateReleaseDescription(version.CurrentVersion.Original(), cl)
@@ -29,6 +30,7 @@ func (g Client) CreateRelease(baseBranch string, version config.Versions, descri
 		TargetCommitish: github.String(baseBranch),
 		Name:            github.String("Release " + version.CurrentVersion.Original()),
 		Body:            github.String(description),
+		Draft:           github.Bool(g.Draft),
 	}
 
 	owner, repo := parseOwnerRepoFromURL(g.ProjectURL)
@@ -39,6 +41,7 @@ func (g Client) CreateRelease(baseBranch string, version config.Versions, descri
 		fmt.Printf("Target commitish: %s\n", *release.TargetCommitish)
 		fmt.Printf("Name: %s\n", *release.Name)
 		fmt.Printf("Body: %s\n", *release.Body)
+		fmt.Printf("Draft: %t\n", *release.Draft)
 		return nil
 	}
 
@@ -47,6 +50,10 @@ func (g Client) CreateRelease(baseBranch string, version config.Versions, descri
 		return err
 	}
 
+	if g.Draft {
+		fmt.Println("Draft release created successfully, publish it with git-releaser release publish.")
+		return nil
+	}
 	fmt.Println("Release created successfully.")
 	return nil
 }
@@ -54,6 +61,9 @@ func (g Client) CreateRelease(baseBranch string, version config.Versions, descri
 func parseOwnerRepoFromURL(url string) (string, string) {
 	// Assuming URL is of the form "https://github.com/owner/repo"
 	parts := strings.Split(url, "/")
+	if len(parts) < 2 {
+		return "", ""
+	}
 	return parts[len(parts)-2], parts[len(parts)-1]
 }
 
@@ -71,7 +81,77 @@ func (g Client) CheckRelease(version config.Versions) (bool, error) {
 		}
 	}
 
-	return false, nil
+	// The tag of a draft release is only created when it is published
+	draft, err := g.findDraftRelease(owner, repo, version.CurrentVersion.Original())
+	if err != nil {
+		return false, err
+	}
+	return draft != nil, nil
+}
+
+// findDraftRelease returns the draft release with the given tag, or nil if there is none
+func (g Client) findDraftRelease(owner string, repo string, tag string) (*github.RepositoryRelease, error) {
+	opt := &github.ListOptions{PerPage: 100}
+	for {
+		releases, resp, err := g.GHClient.Repositories.ListReleases(g.Context, owner, repo, opt)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, release := range releases {
+			if release.GetDraft() && release.GetTagName() == tag {
+				return release, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			return nil, nil
+		}
+		opt.Page = resp.NextPage
+	}
+}
+
+// getReleaseByTag returns the release with the given tag, including draft releases which can't be
+// looked up by their tag
+func (g Client) getReleaseByTag(owner string, repo string, tag string) (*github.RepositoryRelease, error) {
+	release, _, err := g.GHClient.Repositories.GetReleaseByTag(g.Context, owner, repo, tag)
+	if err == nil {
+		return release, nil
+	}
+
+	draft, draftErr := g.findDraftRelease(owner, repo, tag)
+	if draftErr != nil || draft == nil {
+		return nil, err
+	}
+	return draft, nil
+}
+
+// PublishRelease publishes the draft release with the given tag, which creates its tag
+func (g Client) PublishRelease(tag string) error {
+	owner, repo := parseOwnerRepoFromURL(g.ProjectURL)
+
+	release, err := g.findDraftRelease(owner, repo, tag)
+	if err != nil {
+		return err
+	}
+	if release == nil {
+		return fmt.Errorf("there is no draft release for %s", tag)
+	}
+
+	if g.DryRun {
+		fmt.Printf("Dry run: would publish the draft release %s\n", tag)
+		return nil
+	}
+
+	_, _, err = g.GHClient.Repositories.EditRelease(g.Context, owner, repo, release.GetID(), &github.RepositoryRelease{
+		Draft: github.Bool(false),
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Release " + tag + " published successfully.")
+	return nil
 }
 
 func (g Client) GetHighestRelease() (semver.Version, error) {
@@ -88,20 +168,140 @@ func (g Client) GetHighestRelease() (semver.Version, error) {
 		return semver.Version{}, err
 	}
 
-	if len(releases) == 0 {
-		return *semver.MustParse("0.0.0"), nil
+	tags := make([]string, len(releases))
+	for i, release := range releases {
+		tags[i] = release.GetTagName()
 	}
 
-	versions := make([]*semver.Version, len(releases))
-	for i, release := range releases {
-		version, err := semver.NewVersion(release.GetTagName())
+	return common.HighestVersion(tags, g.VersionConstraint)
+}
+
+// GetReleaseNotes returns the notes of the release with the given tag
+func (g Client) GetReleaseNotes(tag string) (string, error) {
+	owner, repo := parseOwnerRepoFromURL(g.ProjectURL)
+
+	release, err := g.getReleaseByTag(owner, repo, tag)
+	if err != nil {
+		return "", err
+	}
+
+	return release.GetBody(), nil
+}
+
+// UpdateReleaseNotes replaces the notes of the release with the given tag
+func (g Client) UpdateReleaseNotes(tag string, notes string) error {
+	owner, repo := parseOwnerRepoFromURL(g.ProjectURL)
+
+	release, err := g.getReleaseByTag(owner, repo, tag)
+	if err != nil {
+		return err
+	}
+
+	if g.DryRun {
+		fmt.Printf("Dry run: would update the notes of release %s to:\n%s\n", tag, notes)
+		return nil
+	}
+
+	_, _, err = g.GHClient.Repositories.EditRelease(g.Context, owner, repo, release.GetID(), &github.RepositoryRelease{
+		Body: github.String(notes),
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Release notes updated successfully.")
+	return nil
+}
+
+// CreateTag creates a tag pointing to the commit of ref, e.g. a nested Go module tag for a release
+func (g Client) CreateTag(tag string, ref string) error {
+	owner, repo := parseOwnerRepoFromURL(g.ProjectURL)
+
+	if g.DryRun {
+		fmt.Printf("Dry run: would create tag %s at %s\n", tag, ref)
+		return nil
+	}
+
+	sha, _, err := g.GHClient.Repositories.GetCommitSHA1(g.Context, owner, repo, ref, "")
+	if err != nil {
+		return err
+	}
+
+	_, _, err = g.GHClient.Git.CreateRef(g.Context, owner, repo, &github.Reference{
+		Ref:    github.String("refs/tags/" + tag),
+		Object: &github.GitObject{SHA: github.String(sha)},
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Tag " + tag + " created successfully.")
+	return nil
+}
+
+// GetCommitSHA returns the SHA of the commit ref points to
+func (g Client) GetCommitSHA(ref string) (string, error) {
+	owner, repo := parseOwnerRepoFromURL(g.ProjectURL)
+
+	sha, _, err := g.GHClient.Repositories.GetCommitSHA1(g.Context, owner, repo, ref, "")
+	if err != nil {
+		return "", err
+	}
+	return sha, nil
+}
+
+// ListTags returns the names of all tags of the repository
+func (g Client) ListTags() ([]string, error) {
+	owner, repo := parseOwnerRepoFromURL(g.ProjectURL)
+
+	var names []string
+	opt := &github.ListOptions{PerPage: 100}
+	for {
+		tags, resp, err := g.GHClient.Repositories.ListTags(g.Context, owner, repo, opt)
 		if err != nil {
-			continue // Ignore invalid versions
+			return nil, err
 		}
-		versions[i] = version
+		for _, tag := range tags {
+			names = append(names, tag.GetName())
+		}
+
+		if resp.NextPage == 0 {
+			return names, nil
+		}
+		opt.Page = resp.NextPage
+	}
+}
+
+// MoveTag points the tag to the commit of ref, creating it if it doesn't exist yet
+func (g Client) MoveTag(tag string, ref string) error {
+	owner, repo := parseOwnerRepoFromURL(g.ProjectURL)
+
+	if g.DryRun {
+		fmt.Printf("Dry run: would move tag %s to %s\n", tag, ref)
+		return nil
+	}
+
+	sha, _, err := g.GHClient.Repositories.GetCommitSHA1(g.Context, owner, repo, ref, "")
+	if err != nil {
+		return err
 	}
 
-	sort.Sort(semver.Collection(versions))
+	reference := &github.Reference{
+		Ref:    github.String("refs/tags/" + tag),
+		Object: &github.GitObject{SHA: github.String(sha)},
+	}
 
-	return *versions[len(versions)-1], nil
+	_, resp, err := g.GHClient.Git.GetRef(g.Context, owner, repo, "tags/"+tag)
+	switch {
+	case err == nil:
+		_, _, err = g.GHClient.Git.UpdateRef(g.Context, owner, repo, reference, true)
+	case resp != nil && resp.StatusCode == http.StatusNotFound:
+		_, _, err = g.GHClient.Git.CreateRef(g.Context, owner, repo, reference)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Tag " + tag + " moved to " + ref + ".")
+	return nil
 }
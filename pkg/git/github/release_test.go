@@ -0,0 +1,45 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestGetReleaseNotes(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     string
+		want    string
+		wantErr bool
+	}{
+		{name: "published release", tag: "v1.0.0", want: "published notes"},
+		{name: "draft release", tag: "v1.1.0", want: "draft notes"},
+		{name: "missing release", tag: "v2.0.0", wantErr: true},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/owner/repo/releases/tags/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/repo/releases/tags/v1.0.0" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"id": 1, "tag_name": "v1.0.0", "body": "published notes"}`)
+	})
+	mux.HandleFunc("/repos/owner/repo/releases", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id": 1, "tag_name": "v1.0.0", "body": "published notes"}, {"id": 2, "tag_name": "v1.1.0", "body": "draft notes", "draft": true}]`)
+	})
+	g := newTestClient(t, mux)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := g.GetReleaseNotes(tt.tag)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetReleaseNotes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("GetReleaseNotes() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/git-releaser/git-releaser/pkg/assets"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// packageNameRegex matches the characters which are not allowed in names of generic packages
+var packageNameRegex = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+type ReleaseLink struct {
+	ID             int    `json:"id"`
+	Name           string `json:"name"`
+	URL            string `json:"url"`
+	DirectAssetURL string `json:"direct_asset_url"`
+}
+
+// UploadReleaseAssets uploads the assets to the generic package registry and links them in the release
+// with the given tag. Existing links to the same file are replaced.
+func (g Client) UploadReleaseAssets(tag string, releaseAssets []assets.Asset) error {
+	if g.DryRun {
+		for _, asset := range releaseAssets {
+			fmt.Printf("Dry run: would upload %s as %s (%s) to release %s\n", asset.Path, asset.Name, asset.ContentType, tag)
+		}
+		return nil
+	}
+
+	links, err := g.getReleaseLinks(tag)
+	if err != nil {
+		return err
+	}
+
+	for _, asset := range releaseAssets {
+		packageURL, err := g.uploadGenericPackage(tag, asset)
+		if err != nil {
+			return fmt.Errorf("could not upload asset %s: %w", asset.Name, err)
+		}
+
+		name := asset.Name
+		if asset.Label != "" {
+			name = asset.Label
+		}
+
+		for _, link := range links {
+			if link.URL == packageURL || strings.HasSuffix(link.DirectAssetURL, "/downloads/"+asset.Name) {
+				err = g.deleteReleaseLink(tag, link.ID)
+				if err != nil {
+					return fmt.Errorf("could not replace asset %s: %w", asset.Name, err)
+				}
+			}
+		}
+
+		err = g.createReleaseLink(tag, name, packageURL, asset.Name)
+		if err != nil {
+			return fmt.Errorf("could not link asset %s: %w", asset.Name, err)
+		}
+		fmt.Println("Asset " + asset.Name + " uploaded successfully.")
+	}
+
+	return nil
+}
+
+// uploadGenericPackage uploads an asset as file of the generic package of the project for the tag and returns its URL
+func (g Client) uploadGenericPackage(tag string, asset assets.Asset) (string, error) {
+	content, err := os.ReadFile(asset.Path)
+	if err != nil {
+		return "", err
+	}
+
+	packageURL := fmt.Sprintf("%s/projects/%d/packages/generic/%s/%s/%s", g.ApiURL, g.ProjectID,
+		url.PathEscape(g.genericPackageName()), url.PathEscape(tag), url.PathEscape(asset.Name))
+
+	resp, err := g.gitLabRequest(Request{
+		URL:         packageURL,
+		Method:      http.MethodPut,
+		Payload:     content,
+		ContentType: asset.ContentType,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to upload package file. Status code: %d, Body: %s", resp.StatusCode, resp.Body)
+	}
+
+	return packageURL, nil
+}
+
+// genericPackageName returns the name of the generic package for release assets, which is the project name
+func (g Client) genericPackageName() string {
+	name := packageNameRegex.ReplaceAllString(path.Base(strings.TrimSuffix(g.ProjectURL, "/")), "-")
+	if name == "" || name == "." || name == "-" {
+		return "release"
+	}
+	return name
+}
+
+func (g Client) getReleaseLinks(tag string) ([]ReleaseLink, error) {
+	req := Request{
+		URL:    fmt.Sprintf("%s/projects/%d/releases/%s/assets/links", g.ApiURL, g.ProjectID, url.PathEscape(tag)),
+		Method: http.MethodGet,
+	}
+
+	resp, err := g.gitLabRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch release links. Status code: %d", resp.StatusCode)
+	}
+
+	var links []ReleaseLink
+	if err := json.Unmarshal(resp.Body, &links); err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+func (g Client) deleteReleaseLink(tag string, id int) error {
+	req := Request{
+		URL:    fmt.Sprintf("%s/projects/%d/releases/%s/assets/links/%d", g.ApiURL, g.ProjectID, url.PathEscape(tag), id),
+		Method: http.MethodDelete,
+	}
+
+	resp, err := g.gitLabRequest(req)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed to delete release link. Status code: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (g Client) createReleaseLink(tag string, name string, linkURL string, fileName string) error {
+	var err error
+	req := Request{
+		URL:    fmt.Sprintf("%s/projects/%d/releases/%s/assets/links", g.ApiURL, g.ProjectID, url.PathEscape(tag)),
+		Method: http.MethodPost,
+	}
+
+	payload := map[string]interface{}{
+		"name":              name,
+		"url":               linkURL,
+		"direct_asset_path": "/" + fileName,
+		"link_type":         "package",
+	}
+
+	req.Payload, err = json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	resp, err := g.gitLabRequest(req)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("failed to create release link. Status code: %d, Body: %s", resp.StatusCode, resp.Body)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/git-releaser/git-releaser/pkg/assets"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestUploadReleaseAssets(t *testing.T) {
+	dir := t.TempDir()
+	var releaseAssets []assets.Asset
+	for _, name := range []string{"app-linux.tar.gz", "app-darwin.tar.gz"} {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+		releaseAssets = append(releaseAssets, assets.Asset{Name: name, Path: p, Label: "Binary (" + name + ")", ContentType: "application/gzip"})
+	}
+
+	var mu sync.Mutex
+	var deleted []string
+	var created []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodPut:
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodGet && r.URL.Path == "/projects/1/releases/v1.0.0/assets/links":
+			// The link of an earlier upload of app-linux.tar.gz and an unrelated link with the same label
+			fmt.Fprint(w, `[
+				{"id": 1, "name": "Binary", "url": "https://example.com/old", "direct_asset_url": "https://gitlab.com/group/app/-/releases/v1.0.0/downloads/app-linux.tar.gz"},
+				{"id": 2, "name": "Binary", "url": "https://example.com/docs"}
+			]`)
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPost && r.URL.Path == "/projects/1/releases/v1.0.0/assets/links":
+			body, _ := io.ReadAll(r.Body)
+			var link map[string]string
+			if err := json.Unmarshal(body, &link); err != nil {
+				t.Error(err)
+				return
+			}
+			created = append(created, link["name"])
+			w.WriteHeader(http.StatusCreated)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	g := Client{ApiURL: server.URL, ProjectID: 1, ProjectURL: "https://gitlab.com/group/app"}
+	err := g.UploadReleaseAssets("v1.0.0", releaseAssets)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantDeleted := []string{"/projects/1/releases/v1.0.0/assets/links/1"}
+	if !reflect.DeepEqual(deleted, wantDeleted) {
+		t.Errorf("deleted links %q, want %q", deleted, wantDeleted)
+	}
+	wantCreated := []string{"Binary (app-linux.tar.gz)", "Binary (app-darwin.tar.gz)"}
+	if !reflect.DeepEqual(created, wantCreated) {
+		t.Errorf("created links %q, want %q", created, wantCreated)
+	}
+}
@@ -8,18 +8,36 @@ import (
 	"github.com/git-releaser/git-releaser/pkg/git/common"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
-func (g Client) CommitManifest(branchName string, content string, versions releaserconfig.Versions, extraFiles []releaserconfig.ExtraFileConfig) error {
-	err := g.GoGitConfig.CommitManifest(branchName, content, versions, extraFiles, g.DryRun)
+func (g Client) PrepareManifest(content string, versions releaserconfig.Versions, extraFiles []releaserconfig.ExtraFileConfig) (common.ManifestChanges, error) {
+	return g.GoGitConfig.PrepareManifest(content, versions, extraFiles, g.DryRun)
+}
+
+func (g Client) CommitManifest(branchName string, changes common.ManifestChanges, versions releaserconfig.Versions) error {
+	err := g.GoGitConfig.CommitManifest(branchName, changes, versions, g.DryRun)
 	return err
 }
 
 func (g Client) CommitFile(branchName string, changeset []common.ChangeSet) error {
+	if g.DryRun {
+		fmt.Printf("Dry run: would commit and push %d changed file(s) to %s\n", len(changeset), branchName)
+		return nil
+	}
 	err := g.GoGitConfig.CommitFile(branchName, changeset)
 	return err
 }
 
+// CreateSignedTag creates a signed annotated tag for the commit of ref locally and pushes it
+func (g Client) CreateSignedTag(tag string, ref string, message string) error {
+	if g.DryRun {
+		fmt.Printf("Dry run: would create and push signed tag %s at %s\n", tag, ref)
+		return nil
+	}
+	return g.GoGitConfig.CreateSignedTag(tag, ref, message)
+}
+
 func (g Client) GetCommitsSinceRelease(sinceRelease string) ([]changelog.Commit, error) {
 	var req Request
 	var tagDate string
@@ -52,9 +70,53 @@ func (g Client) GetCommitsSinceRelease(sinceRelease string) ([]changelog.Commit,
 		return nil, err
 	}
 
+	for i := range commits {
+		commits[i].CoAuthors = changelog.ParseCoAuthors(commits[i].Message)
+	}
+	g.addUsernames(commits)
+
 	return commits, nil
 }
 
+// addUsernames sets the GitLab usernames of the commit authors. GitLab only finds users by their public
+// email, authors without one are mentioned by their name.
+func (g Client) addUsernames(commits []changelog.Commit) {
+	usernames := make(map[string]string)
+	for i, commit := range commits {
+		email := strings.ToLower(commit.AuthorEmail)
+		if email == "" {
+			continue
+		}
+
+		username, ok := usernames[email]
+		if !ok {
+			username = g.getUsernameByEmail(email)
+			usernames[email] = username
+		}
+		commits[i].Username = username
+	}
+}
+
+// getUsernameByEmail returns the username of the user with the given public email, or an empty string if there
+// is no such user
+func (g Client) getUsernameByEmail(email string) string {
+	req := Request{
+		URL:    fmt.Sprintf("%s/users?search=%s", g.ApiURL, url.QueryEscape(email)),
+		Method: http.MethodGet,
+	}
+
+	resp, err := g.gitLabRequest(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return ""
+	}
+
+	var users []User
+	if err := json.Unmarshal(resp.Body, &users); err != nil || len(users) != 1 {
+		return ""
+	}
+	return users[0].Username
+}
+
 func (g Client) getTagCommitDate(tag string) (string, error) {
 	req := Request{
 		URL: fmt.Sprintf("%s/projects/%d/repository/tags/%s", g.ApiURL, g.ProjectID, url.PathEscape(tag)),
@@ -78,3 +140,29 @@ func (g Client) getTagCommitDate(tag string) (string, error) {
 	}
 	return tagDetails.Commit.CommittedDate, nil
 }
+
+// GenerateChangelog creates the changelog for all commits since the given release
+func (g Client) GenerateChangelog(sinceRelease string) (string, error) {
+	commits, err := g.GetCommitsSinceRelease(sinceRelease)
+	if err != nil {
+		return "", err
+	}
+
+	return g.ChangelogFromCommits(commits, sinceRelease), nil
+}
+
+// ChangelogFromCommits creates the changelog for the given commits of the release following sinceRelease
+func (g Client) ChangelogFromCommits(commits []changelog.Commit, sinceRelease string) string {
+	commits = common.AddPatchIDs("", commits)
+
+	var conventionalCommits []changelog.ConventionalCommit
+	if g.Changelog.Source == releaserconfig.ChangelogSourcePullRequests {
+		pullRequests, remaining := g.getPullRequestsForCommits(commits)
+		conventionalCommits = append(changelog.ParsePullRequests(pullRequests, g.Changelog.LabelSections), changelog.ParseCommits(remaining)...)
+	} else {
+		conventionalCommits = changelog.ParseCommits(commits)
+	}
+
+	opts := common.NewChangelogOptions(g.Changelog, sinceRelease)
+	return changelog.GenerateChangelogWithOptions(conventionalCommits, g.ProjectURL, opts)
+}
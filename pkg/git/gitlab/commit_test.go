@@ -0,0 +1,44 @@
+package gitlab
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetCommitsSinceReleaseUsernames(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/projects/1/repository/commits":
+			fmt.Fprint(w, `[
+				{"id": "a", "message": "feat: a", "author_name": "Jane", "author_email": "Jane@example.com"},
+				{"id": "b", "message": "fix: b", "author_name": "Jane", "author_email": "jane@example.com"},
+				{"id": "c", "message": "fix: c", "author_name": "John", "author_email": "john@example.com"}
+			]`)
+		case r.URL.Path == "/users" && r.URL.Query().Get("search") == "jane@example.com":
+			fmt.Fprint(w, `[{"id": 1, "name": "Jane", "username": "jane"}]`)
+		case r.URL.Path == "/users":
+			fmt.Fprint(w, `[]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	g := Client{ApiURL: server.URL, ProjectID: 1}
+	commits, err := g.GetCommitsSinceRelease("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"jane", "jane", ""}
+	if len(commits) != len(want) {
+		t.Fatalf("got %d commits, want %d", len(commits), len(want))
+	}
+	for i, commit := range commits {
+		if commit.Username != want[i] {
+			t.Errorf("commit %s: Username = %q, want %q", commit.ID, commit.Username, want[i])
+		}
+	}
+}
@@ -16,6 +16,10 @@ type Client struct {
 	ProjectURL         string
 	PropagationTargets []config.PropagationTarget
 	ConfigUpdates      []config.ConfigUpdate
+	Changelog          config.ChangelogConfig
+	PullRequest        config.PullRequestConfig
+	Draft              bool
+	VersionConstraint  string
 	DryRun             bool
 	GoGitConfig        common.GoGitRepository
 }
@@ -24,6 +28,8 @@ type Request struct {
 	Method  string
 	URL     string
 	Payload []byte
+	// ContentType of the payload, JSON by default
+	ContentType string
 }
 
 type Response struct {
@@ -31,8 +37,12 @@ type Response struct {
 	Body       []byte
 }
 
-func (g Client) ReplaceTaggedLines(filenames []string, sourceTag string, replaceTag string) ([]common.ChangeSet, error) {
-	return g.GoGitConfig.ReplaceTaggedLines(filenames, sourceTag, replaceTag)
+func (g Client) ReplaceTaggedLines(patterns []string, excludes []string, sourceTag string, replaceTag string) ([]common.ChangeSet, error) {
+	return g.GoGitConfig.ReplaceTaggedLines(patterns, excludes, sourceTag, replaceTag)
+}
+
+func (g Client) ReplaceTags(replacements []config.TagReplacement) ([]common.ChangeSet, error) {
+	return g.GoGitConfig.ReplaceTags(replacements)
 }
 
 func (g Client) gitLabRequest(request Request) (Response, error) {
@@ -56,7 +66,11 @@ func (g Client) gitLabRequest(request Request) (Response, error) {
 		}
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	if request.ContentType == "" {
+		request.ContentType = "application/json"
+	}
+
+	req.Header.Set("Content-Type", request.ContentType)
 	req.Header.Set("PRIVATE-TOKEN", g.AccessToken)
 
 	client := &http.Client{}
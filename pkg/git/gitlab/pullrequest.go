@@ -8,6 +8,8 @@ import (
 	"github.com/git-releaser/git-releaser/pkg/helpers"
 	"github.com/git-releaser/git-releaser/pkg/naming"
 	"net/http"
+	"net/url"
+	"strings"
 )
 
 func (g Client) CheckCreateReleasePullRequest(source string, target string, versions config.Versions) error {
@@ -17,17 +19,19 @@ func (g Client) CheckCreateReleasePullRequest(source string, target string, vers
 		return err
 	}
 
-	commits, _ := g.GetCommitsSinceRelease(versions.CurrentVersion.Original())
-	conventionalCommits := changelog.ParseCommits(commits)
-	cl := changelog.GenerateChangelog(conventionalCommits, g.ProjectURL)
+	cl, err := g.GenerateChangelog(versions.CurrentVersion.Original())
+	if err != nil {
+		fmt.Println("Could not generate changelog: " + err.Error())
+	}
 
 	m := MergeRequest{
 		SourceBranch: source,
 		TargetBranch: target,
 		Title:        naming.GeneratePrTitle(versions.NextVersion.Original()),
 		Description:  naming.CreatePrDescription(versions.NextVersion.Original(), cl, g.PropagationTargets, g.ConfigUpdates),
-		Labels:       []string{"release"},
+		Labels:       append([]string{"release", naming.LabelPending}, g.PullRequest.Labels...),
 	}
+	g.configureMergeRequest(&m, versions)
 
 	if existingPR.IID != 0 {
 		// If the pull request already exists, update its description
@@ -54,7 +58,7 @@ func (g Client) CheckCreateReleasePullRequest(source string, target string, vers
 	return nil
 }
 
-func (g Client) CheckCreateFileMergeRequest(source string, target string) error {
+func (g Client) CheckCreateFileMergeRequest(source string, target string, title string, description string) error {
 	fmt.Println("Checking if a pull request for the file update already exists")
 	// Check if a pull request with the same source and target branches already exists
 	existingPR, err := g.getMergeRequestBySourceAndTarget(source, target)
@@ -65,19 +69,31 @@ func (g Client) CheckCreateFileMergeRequest(source string, target string) error
 	m := MergeRequest{
 		SourceBranch: source,
 		TargetBranch: target,
-		Title:        fmt.Sprintf("Updating %s to %s", source, target),
+		Title:        title,
+		Description:  description,
 		Labels:       []string{"release-updates"},
 	}
 
 	fmt.Println("Checking if pull request already exists")
 	if existingPR.IID != 0 {
-		// If the pull request already exists, update its description
+		// If the pull request already exists, update its title and description
 		fmt.Println("Pull request already exists, will update it")
-		err := existingPR.Update(g)
+		if g.DryRun {
+			return nil
+		}
+
+		m.IID = existingPR.IID
+		err := m.Update(g)
 		if err != nil {
 			return err
 		}
 
+	} else if g.DryRun {
+		fmt.Println("Dry run: pull request would be created with the following details:")
+		fmt.Println("Title: " + title)
+		fmt.Println("Description: " + description)
+		fmt.Println("Source branch: " + source)
+		fmt.Println("Target branch: " + target)
 	} else {
 		fmt.Println("Pull request does not exist, will create it")
 		err := m.Create(g)
@@ -88,6 +104,138 @@ func (g Client) CheckCreateFileMergeRequest(source string, target string) error
 	return nil
 }
 
+// configureMergeRequest sets the assignees, reviewers and milestone of a release merge request. Failures are
+// reported, but don't fail the release merge request.
+func (g Client) configureMergeRequest(m *MergeRequest, versions config.Versions) {
+	settings := g.PullRequest
+
+	if len(settings.TeamReviewers) > 0 {
+		fmt.Println("Team reviewers are only supported on GitHub, add the users as reviewers instead")
+	}
+
+	var err error
+	m.AssigneeIDs, err = g.getUserIDs(settings.Assignees)
+	if err != nil {
+		fmt.Println("Could not find the assignees: " + err.Error())
+	}
+	m.ReviewerIDs, err = g.getUserIDs(settings.Reviewers)
+	if err != nil {
+		fmt.Println("Could not find the reviewers: " + err.Error())
+	}
+
+	if settings.Milestone != "" {
+		title, err := naming.CreateMilestoneTitle(settings.Milestone, versions.NextVersion)
+		if err != nil {
+			fmt.Println("Could not render the milestone: " + err.Error())
+			return
+		}
+
+		m.MilestoneID, err = g.getOrCreateMilestone(title)
+		if err != nil {
+			fmt.Println("Could not create milestone " + title + ": " + err.Error())
+		}
+	}
+}
+
+// addSettings adds the assignees, reviewers and milestone to the payload of a merge request if they are set
+func (m MergeRequest) addSettings(payload map[string]interface{}) {
+	if len(m.AssigneeIDs) > 0 {
+		payload["assignee_ids"] = m.AssigneeIDs
+	}
+	if len(m.ReviewerIDs) > 0 {
+		payload["reviewer_ids"] = m.ReviewerIDs
+	}
+	if m.MilestoneID != 0 {
+		payload["milestone_id"] = m.MilestoneID
+	}
+}
+
+// getUserIDs looks up the IDs of the users with the given usernames
+func (g Client) getUserIDs(usernames []string) ([]int, error) {
+	var ids []int
+	for _, username := range usernames {
+		req := Request{
+			URL:    fmt.Sprintf("%s/users?username=%s", g.ApiURL, url.QueryEscape(username)),
+			Method: http.MethodGet,
+		}
+
+		resp, err := g.gitLabRequest(req)
+		if err != nil {
+			return ids, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return ids, fmt.Errorf("failed to fetch user %s. Status code: %d", username, resp.StatusCode)
+		}
+
+		var users []User
+		if err := json.Unmarshal(resp.Body, &users); err != nil {
+			return ids, err
+		}
+		if len(users) == 0 {
+			return ids, fmt.Errorf("user %s not found", username)
+		}
+		ids = append(ids, users[0].ID)
+	}
+	return ids, nil
+}
+
+// getOrCreateMilestone returns the ID of the project milestone with the given title, which is created if it
+// doesn't exist
+func (g Client) getOrCreateMilestone(title string) (int, error) {
+	req := Request{
+		URL:    fmt.Sprintf("%s/projects/%d/milestones?title=%s", g.ApiURL, g.ProjectID, url.QueryEscape(title)),
+		Method: http.MethodGet,
+	}
+
+	resp, err := g.gitLabRequest(req)
+	if err != nil {
+		return 0, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("failed to fetch milestones. Status code: %d", resp.StatusCode)
+	}
+
+	var milestones []Milestone
+	if err := json.Unmarshal(resp.Body, &milestones); err != nil {
+		return 0, err
+	}
+	if len(milestones) > 0 {
+		return milestones[0].ID, nil
+	}
+
+	if g.DryRun {
+		fmt.Println("Dry run: would create milestone " + title)
+		return 0, nil
+	}
+
+	req = Request{
+		URL:    fmt.Sprintf("%s/projects/%d/milestones", g.ApiURL, g.ProjectID),
+		Method: http.MethodPost,
+	}
+	req.Payload, err = json.Marshal(map[string]interface{}{"title": title})
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err = g.gitLabRequest(req)
+	if err != nil {
+		return 0, err
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return 0, fmt.Errorf("failed to create milestone. Status code: %d, Body: %s", resp.StatusCode, resp.Body)
+	}
+
+	var milestone Milestone
+	if err := json.Unmarshal(resp.Body, &milestone); err != nil {
+		return 0, err
+	}
+	fmt.Println("Milestone " + title + " created.")
+	return milestone.ID, nil
+}
+
 func (g Client) closeOldPullRequests(currentSource string) error {
 	mergeRequests, err := g.getMergeRequests()
 	if err != nil {
@@ -156,6 +304,7 @@ func (m MergeRequest) Create(g Client) error {
 		"description":   m.Description,
 		"labels":        m.Labels,
 	}
+	m.addSettings(payload)
 
 	req.Payload, err = json.Marshal(payload)
 	if err != nil {
@@ -191,6 +340,7 @@ func (m MergeRequest) Update(g Client) error {
 		"description":   m.Description,
 		"labels":        m.Labels,
 	}
+	m.addSettings(payload)
 
 	req.Payload, err = json.Marshal(payload)
 	if err != nil {
@@ -211,6 +361,80 @@ func (m MergeRequest) Update(g Client) error {
 	return nil
 }
 
+// GetMergedPullRequest returns the merged merge request from source into target, or nil if there is none
+func (g Client) GetMergedPullRequest(source string, target string) (*changelog.PullRequest, error) {
+	query := url.Values{}
+	query.Set("state", "merged")
+	query.Set("source_branch", source)
+	query.Set("target_branch", target)
+
+	req := Request{
+		URL:    fmt.Sprintf("%s/projects/%d/merge_requests?%s", g.ApiURL, g.ProjectID, query.Encode()),
+		Method: http.MethodGet,
+	}
+
+	resp, err := g.gitLabRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch merge requests. Status code: %d, Body: %s", resp.StatusCode, resp.Body)
+	}
+
+	var mergeRequests []MergeRequest
+	if err := json.Unmarshal(resp.Body, &mergeRequests); err != nil {
+		return nil, err
+	}
+	if len(mergeRequests) == 0 {
+		return nil, nil
+	}
+
+	mr := mergeRequests[0]
+	return &changelog.PullRequest{
+		Number: mr.IID,
+		Title:  mr.Title,
+		Body:   mr.Description,
+		URL:    mr.WebURL,
+		Labels: mr.Labels,
+		Author: changelog.Author{Name: mr.Author.Name, Username: mr.Author.Username},
+	}, nil
+}
+
+// SetPullRequestLabels adds and removes labels of a merge request
+func (g Client) SetPullRequestLabels(number int, add []string, remove []string) error {
+	var err error
+	req := Request{
+		URL:    fmt.Sprintf("%s/projects/%d/merge_requests/%d", g.ApiURL, g.ProjectID, number),
+		Method: http.MethodPut,
+	}
+
+	payload := map[string]interface{}{
+		"add_labels":    strings.Join(add, ","),
+		"remove_labels": strings.Join(remove, ","),
+	}
+
+	req.Payload, err = json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	if g.DryRun {
+		fmt.Printf("Dry run: would add the labels %v to merge request !%d and remove %v\n", add, number, remove)
+		return nil
+	}
+
+	resp, err := g.gitLabRequest(req)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to update the labels of merge request !%d. Status code: %d, Body: %s", number, resp.StatusCode, resp.Body)
+	}
+	return nil
+}
+
 func (g Client) getMergeRequestBySourceAndTarget(source, target string) (MergeRequest, error) {
 	mergeRequests, err := g.getMergeRequests()
 	if err != nil {
@@ -253,3 +477,112 @@ func (g Client) getMergeRequests() ([]MergeRequest, error) {
 
 	return mergeRequests, nil // No existing pull request found
 }
+
+// getPullRequestsForCommits resolves the merged merge requests the given commits belong to.
+// Commits which are not part of a merged merge request are returned separately.
+func (g Client) getPullRequestsForCommits(commits []changelog.Commit) ([]changelog.PullRequest, []changelog.Commit) {
+	var pullRequests []changelog.PullRequest
+	var remaining []changelog.Commit
+	seen := make(map[int]struct{})
+
+	for _, commit := range commits {
+		req := Request{
+			URL:    fmt.Sprintf("%s/projects/%d/repository/commits/%s/merge_requests", g.ApiURL, g.ProjectID, commit.ID),
+			Method: http.MethodGet,
+		}
+
+		resp, err := g.gitLabRequest(req)
+		if err != nil || resp.StatusCode != http.StatusOK {
+			fmt.Println("gitlab: could not get merge requests for commit " + commit.ID)
+			remaining = append(remaining, commit)
+			continue
+		}
+
+		var mergeRequests []MergeRequest
+		if err := json.Unmarshal(resp.Body, &mergeRequests); err != nil {
+			remaining = append(remaining, commit)
+			continue
+		}
+
+		found := false
+		for _, mr := range mergeRequests {
+			if mr.State != "merged" {
+				continue
+			}
+			found = true
+			if _, exists := seen[mr.IID]; exists {
+				continue
+			}
+			seen[mr.IID] = struct{}{}
+
+			pullRequests = append(pullRequests, changelog.PullRequest{
+				Number: mr.IID,
+				Title:  mr.Title,
+				Body:   mr.Description,
+				URL:    mr.WebURL,
+				Labels: mr.Labels,
+				Author: changelog.Author{Name: mr.Author.Name, Username: mr.Author.Username},
+			})
+		}
+
+		if !found {
+			remaining = append(remaining, commit)
+		}
+	}
+
+	return pullRequests, remaining
+}
+
+// EnableAutoMerge sets the open merge request from source into target to merge when its pipeline succeeds.
+// With the squash method the commits are squashed, otherwise the merge method of the project is used.
+func (g Client) EnableAutoMerge(source string, target string, method string) error {
+	existingPR, err := g.getMergeRequestBySourceAndTarget(source, target)
+	if err != nil {
+		return err
+	}
+	if existingPR.IID == 0 {
+		if g.DryRun {
+			fmt.Println("Dry run: would merge the merge request from " + source + " when the pipeline succeeds")
+			return nil
+		}
+		return fmt.Errorf("there is no open merge request from %s into %s", source, target)
+	}
+
+	req := Request{
+		URL:    fmt.Sprintf("%s/projects/%d/merge_requests/%d/merge", g.ApiURL, g.ProjectID, existingPR.IID),
+		Method: http.MethodPut,
+	}
+
+	payload := map[string]interface{}{
+		"merge_when_pipeline_succeeds": true,
+	}
+	switch method {
+	case "":
+	case "squash":
+		payload["squash"] = true
+	default:
+		fmt.Println("The " + method + " method is only supported on GitHub, the merge method of the project is used")
+	}
+
+	req.Payload, err = json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	if g.DryRun {
+		fmt.Printf("Dry run: would merge merge request !%d when the pipeline succeeds\n", existingPR.IID)
+		return nil
+	}
+
+	resp, err := g.gitLabRequest(req)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to set merge request !%d to merge when the pipeline succeeds. Status code: %d, Body: %s", existingPR.IID, resp.StatusCode, resp.Body)
+	}
+
+	fmt.Printf("Merge request !%d will be merged when the pipeline succeeds.\n", existingPR.IID)
+	return nil
+}
@@ -4,20 +4,27 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/Masterminds/semver"
-	"github.com/git-releaser/git-releaser/pkg/changelog"
 	"github.com/git-releaser/git-releaser/pkg/config"
+	"github.com/git-releaser/git-releaser/pkg/git/common"
 	"github.com/git-releaser/git-releaser/pkg/naming"
 	"net/http"
+	"net/url"
 	"strconv"
+	"time"
 )
 
 type Release struct {
-	ID          int    `json:"id"`
-	TagName     string `json:"tag_name"`
-	Description string `json:"description"`
-	Version     *semver.Version
+	ID              int    `json:"id"`
+	TagName         string `json:"tag_name"`
+	Description     string `json:"description"`
+	UpcomingRelease bool   `json:"upcoming_release"`
+	Version         *semver.Version
 }
 
+// draftReleaseDate is the release date of draft releases. GitLab has no drafts, a release with a date in
+// the future is shown as an upcoming release until it is published.
+const draftReleaseDate = "2999-12-31T00:00:00Z"
+
 func (g Client) CreateRelease(baseBranch string, version config.Versions, description string) error {
 	err := g.createTag(g.ProjectID, baseBranch, version, description)
 	if err != nil {
@@ -85,9 +92,10 @@ func (g Client) createTag(project int, baseBranch string, version config.Version
 	if err != nil {
 		fmt.Println("github: could not get highest release")
 	}
-	commits, _ := g.GetCommitsSinceRelease(highestRelease.Original())
-	conventionalCommits := changelog.ParseCommits(commits)
-	cl := changelog.GenerateChangelog(conventionalCommits, g.ProjectURL)
+	cl, err := g.GenerateChangelog(highestRelease.Original())
+	if err != nil {
+		fmt.Println("Could not generate changelog: " + err.Error())
+	}
 
 	if description == "" {
 		description = naming.CreateReleaseDescription(version.CurrentVersion.Original(), cl)
@@ -98,6 +106,9 @@ func (g Client) createTag(project int, baseBranch string, version config.Version
 		"ref":         baseBranch,
 		"description": description,
 	}
+	if g.Draft {
+		payload["released_at"] = draftReleaseDate
+	}
 
 	req.Payload, err = json.Marshal(payload)
 	if err != nil {
@@ -109,6 +120,7 @@ func (g Client) createTag(project int, baseBranch string, version config.Version
 		fmt.Printf("Tag name: %s\n", version.CurrentVersion.Original())
 		fmt.Printf("Ref: %s\n", baseBranch)
 		fmt.Printf("Description: %s\n", description)
+		fmt.Printf("Draft: %t\n", g.Draft)
 		return nil
 	}
 
@@ -121,6 +133,10 @@ func (g Client) createTag(project int, baseBranch string, version config.Version
 		return fmt.Errorf("failed to create release. Status code: %d", resp.StatusCode)
 	}
 
+	if g.Draft {
+		fmt.Println("Draft release created successfully (" + req.URL + "), publish it with git-releaser release publish.")
+		return nil
+	}
 	fmt.Println("Release created successfully (" + req.URL + ")")
 	return nil
 }
@@ -147,21 +163,237 @@ func (g Client) GetHighestRelease() (semver.Version, error) {
 		return semver.Version{}, err
 	}
 
-	// If there are no releases, return "0.0.0"
-	if len(releases) == 0 {
-		return *semver.MustParse("0.0.0"), nil
+	tags := make([]string, len(releases))
+	for i, release := range releases {
+		tags[i] = release.TagName
+	}
+
+	// Return the version number of the highest release
+	return common.HighestVersion(tags, g.VersionConstraint)
+}
+
+// GetReleaseNotes returns the notes of the release with the given tag
+func (g Client) GetReleaseNotes(tag string) (string, error) {
+	req := Request{
+		URL:    fmt.Sprintf("%s/projects/%d/releases/%s", g.ApiURL, g.ProjectID, url.PathEscape(tag)),
+		Method: http.MethodGet,
+	}
+
+	resp, err := g.gitLabRequest(req)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch release. Status code: %d", resp.StatusCode)
+	}
+
+	var release Release
+	if err := json.Unmarshal(resp.Body, &release); err != nil {
+		return "", err
+	}
+
+	return release.Description, nil
+}
+
+// UpdateReleaseNotes replaces the notes of the release with the given tag
+func (g Client) UpdateReleaseNotes(tag string, notes string) error {
+	var err error
+	req := Request{
+		URL:    fmt.Sprintf("%s/projects/%d/releases/%s", g.ApiURL, g.ProjectID, url.PathEscape(tag)),
+		Method: http.MethodPut,
+	}
+
+	payload := map[string]interface{}{
+		"description": notes,
+	}
+
+	req.Payload, err = json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	if g.DryRun {
+		fmt.Printf("Dry run: would update the notes of release %s to:\n%s\n", tag, notes)
+		return nil
+	}
+
+	resp, err := g.gitLabRequest(req)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to update release. Status code: %d, Body: %s", resp.StatusCode, resp.Body)
+	}
+
+	fmt.Println("Release notes updated successfully.")
+	return nil
+}
+
+// CreateTag creates a tag pointing to the commit of ref, e.g. a nested Go module tag for a release
+func (g Client) CreateTag(tag string, ref string) error {
+	var err error
+	req := Request{
+		URL:    fmt.Sprintf("%s/projects/%d/repository/tags", g.ApiURL, g.ProjectID),
+		Method: http.MethodPost,
 	}
 
-	thisVersion := semver.MustParse("0.0.0")
+	payload := map[string]interface{}{
+		"tag_name": tag,
+		"ref":      ref,
+	}
+
+	req.Payload, err = json.Marshal(payload)
+	if err != nil {
+		return err
+	}
 
-	for _, release := range releases {
-		ver := semver.MustParse(release.TagName)
+	if g.DryRun {
+		fmt.Printf("Dry run: would create tag %s at %s\n", tag, ref)
+		return nil
+	}
 
-		if ver.GreaterThan(thisVersion) {
-			thisVersion = ver
+	resp, err := g.gitLabRequest(req)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("failed to create tag. Status code: %d, Body: %s", resp.StatusCode, resp.Body)
+	}
+
+	fmt.Println("Tag " + tag + " created successfully.")
+	return nil
+}
+
+// GetCommitSHA returns the SHA of the commit ref points to
+func (g Client) GetCommitSHA(ref string) (string, error) {
+	req := Request{
+		URL:    fmt.Sprintf("%s/projects/%d/repository/commits/%s", g.ApiURL, g.ProjectID, url.PathEscape(ref)),
+		Method: http.MethodGet,
+	}
+
+	resp, err := g.gitLabRequest(req)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get commit %s. Status code: %d", ref, resp.StatusCode)
+	}
+
+	var commit struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(resp.Body, &commit); err != nil {
+		return "", err
+	}
+	return commit.ID, nil
+}
+
+// PublishRelease publishes the draft release with the given tag by setting its release date to now
+func (g Client) PublishRelease(tag string) error {
+	req := Request{
+		URL:    fmt.Sprintf("%s/projects/%d/releases/%s", g.ApiURL, g.ProjectID, url.PathEscape(tag)),
+		Method: http.MethodGet,
+	}
+
+	resp, err := g.gitLabRequest(req)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch release. Status code: %d", resp.StatusCode)
+	}
+
+	var release Release
+	if err := json.Unmarshal(resp.Body, &release); err != nil {
+		return err
+	}
+	if !release.UpcomingRelease {
+		return fmt.Errorf("there is no draft release for %s", tag)
+	}
+
+	req.Method = http.MethodPut
+	req.Payload, err = json.Marshal(map[string]interface{}{
+		"released_at": time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+
+	if g.DryRun {
+		fmt.Printf("Dry run: would publish the draft release %s\n", tag)
+		return nil
+	}
+
+	resp, err = g.gitLabRequest(req)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to publish release. Status code: %d, Body: %s", resp.StatusCode, resp.Body)
+	}
+
+	fmt.Println("Release " + tag + " published successfully.")
+	return nil
+}
+
+// ListTags returns the names of all tags of the project
+func (g Client) ListTags() ([]string, error) {
+	var names []string
+	for page := 1; ; page++ {
+		req := Request{
+			URL:    fmt.Sprintf("%s/projects/%d/repository/tags?per_page=100&page=%d", g.ApiURL, g.ProjectID, page),
+			Method: http.MethodGet,
+		}
+
+		resp, err := g.gitLabRequest(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to fetch tags. Status code: %d", resp.StatusCode)
+		}
+
+		var tags []config.Tag
+		if err := json.Unmarshal(resp.Body, &tags); err != nil {
+			return nil, err
+		}
+		if len(tags) == 0 {
+			return names, nil
+		}
+		for _, tag := range tags {
+			names = append(names, tag.Name)
 		}
 	}
+}
 
-	// Return the version number of the highest release
-	return *thisVersion, nil
+// MoveTag points the tag to the commit of ref. GitLab can't update tags, so an existing tag is deleted
+// and created again.
+func (g Client) MoveTag(tag string, ref string) error {
+	if g.DryRun {
+		fmt.Printf("Dry run: would move tag %s to %s\n", tag, ref)
+		return nil
+	}
+
+	req := Request{
+		URL:    fmt.Sprintf("%s/projects/%d/repository/tags/%s", g.ApiURL, g.ProjectID, url.PathEscape(tag)),
+		Method: http.MethodDelete,
+	}
+
+	resp, err := g.gitLabRequest(req)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to delete tag %s. Status code: %d, Body: %s", tag, resp.StatusCode, resp.Body)
+	}
+
+	return g.CreateTag(tag, ref)
 }
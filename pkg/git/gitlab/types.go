@@ -9,4 +9,21 @@ type MergeRequest struct {
 	Description  string   `json:"description"`
 	Labels       []string `json:"labels"`
 	State        string   `json:"state"`
+	WebURL       string   `json:"web_url"`
+	Author       User     `json:"author"`
+	// AssigneeIDs, ReviewerIDs and MilestoneID are only sent, the responses contain the users and milestone
+	AssigneeIDs []int `json:"-"`
+	ReviewerIDs []int `json:"-"`
+	MilestoneID int   `json:"-"`
+}
+
+type Milestone struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+}
+
+type User struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Username string `json:"username"`
 }
@@ -2,11 +2,13 @@ package git
 
 import (
 	"github.com/Masterminds/semver"
+	"github.com/git-releaser/git-releaser/pkg/assets"
 	"github.com/git-releaser/git-releaser/pkg/changelog"
 	"github.com/git-releaser/git-releaser/pkg/config"
 	"github.com/git-releaser/git-releaser/pkg/git/common"
 	"github.com/git-releaser/git-releaser/pkg/git/github"
 	"github.com/git-releaser/git-releaser/pkg/git/gitlab"
+	"github.com/git-releaser/git-releaser/pkg/signing"
 	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
 	"log"
 	"strconv"
@@ -22,19 +24,41 @@ type Config struct {
 	AdditionalConfig   map[string]string
 	PropagationTargets []config.PropagationTarget
 	ConfigUpdates      []config.ConfigUpdate
+	Changelog          config.ChangelogConfig
+	PullRequest        config.PullRequestConfig
+	Author             config.AuthorConfig
+	Signing            config.SigningConfig
+	Draft              bool
+	VersionConstraint  string
 	DryRun             bool
 }
 type Provider interface {
 	CheckCreateBranch(baseBranch string, targetVersion string, prefix string) (string, error)
 	CheckCreateReleasePullRequest(source string, target string, versions config.Versions) error
-	CheckCreateFileMergeRequest(source string, target string) error
-	CommitManifest(branchName string, content string, versions config.Versions, extraFiles []config.ExtraFileConfig) error
+	CheckCreateFileMergeRequest(source string, target string, title string, description string) error
+	GetMergedPullRequest(source string, target string) (*changelog.PullRequest, error)
+	SetPullRequestLabels(number int, add []string, remove []string) error
+	EnableAutoMerge(source string, target string, method string) error
+	PrepareManifest(content string, versions config.Versions, extraFiles []config.ExtraFileConfig) (common.ManifestChanges, error)
+	CommitManifest(branchName string, changes common.ManifestChanges, versions config.Versions) error
 	CommitFile(branchName string, changeset []common.ChangeSet) error
 	CreateRelease(baseBranch string, version config.Versions, description string) error
 	CheckRelease(versions config.Versions) (bool, error)
+	PublishRelease(tag string) error
+	CreateTag(tag string, ref string) error
+	GetCommitSHA(ref string) (string, error)
+	CreateSignedTag(tag string, ref string, message string) error
+	ListTags() ([]string, error)
+	MoveTag(tag string, ref string) error
+	GetReleaseNotes(tag string) (string, error)
+	UpdateReleaseNotes(tag string, notes string) error
+	UploadReleaseAssets(tag string, releaseAssets []assets.Asset) error
 	GetCommitsSinceRelease(version string) ([]changelog.Commit, error)
+	GenerateChangelog(sinceRelease string) (string, error)
+	ChangelogFromCommits(commits []changelog.Commit, sinceRelease string) string
 	GetHighestRelease() (semver.Version, error)
-	ReplaceTaggedLines(filenames []string, sourceTag string, replaceTag string) ([]common.ChangeSet, error)
+	ReplaceTaggedLines(patterns []string, excludes []string, sourceTag string, replaceTag string) ([]common.ChangeSet, error)
+	ReplaceTags(replacements []config.TagReplacement) ([]common.ChangeSet, error)
 }
 
 func NewGitClient(gitconfig Config) Provider {
@@ -48,6 +72,15 @@ func NewGitClient(gitconfig Config) Provider {
 			Username: gitconfig.UserId,
 			Password: gitconfig.AccessToken,
 		},
+		Author: gitconfig.Author,
+	}
+
+	if gitconfig.Signing.IsSet() {
+		signer, err := signing.LoadSigner(gitconfig.Signing.SigningKeyConfig, signing.NamespaceGit)
+		if err != nil {
+			log.Fatal("Could not load the signing key: " + err.Error())
+		}
+		goGitConfig.Signer = signer
 	}
 
 	switch strings.ToLower(gitconfig.Provider) {
@@ -73,6 +106,10 @@ func NewGitClient(gitconfig Config) Provider {
 			PropagationTargets: gitconfig.PropagationTargets,
 			GoGitConfig:        goGitConfig,
 			ConfigUpdates:      gitconfig.ConfigUpdates,
+			Changelog:          gitconfig.Changelog,
+			PullRequest:        gitconfig.PullRequest,
+			Draft:              gitconfig.Draft,
+			VersionConstraint:  gitconfig.VersionConstraint,
 			DryRun:             gitconfig.DryRun,
 		}
 
@@ -89,6 +126,10 @@ func NewGitClient(gitconfig Config) Provider {
 			ApiURL:             gitconfig.ApiUrl,
 			PropagationTargets: gitconfig.PropagationTargets,
 			GoGitConfig:        goGitConfig,
+			Changelog:          gitconfig.Changelog,
+			PullRequest:        gitconfig.PullRequest,
+			Draft:              gitconfig.Draft,
+			VersionConstraint:  gitconfig.VersionConstraint,
 			DryRun:             gitconfig.DryRun,
 		})
 	}
@@ -0,0 +1,36 @@
+package git
+
+import (
+	"github.com/git-releaser/git-releaser/pkg/changelog"
+	"github.com/git-releaser/git-releaser/pkg/helpers"
+	"github.com/git-releaser/git-releaser/pkg/naming"
+)
+
+// ReleaseAction is what update does for the version in the manifest
+type ReleaseAction int
+
+const (
+	// ReleaseNone means the version has been released, the next release pull request is prepared
+	ReleaseNone ReleaseAction = iota
+	// ReleaseCreate creates the release and marks the release pull request as tagged
+	ReleaseCreate
+	// ReleaseMarkTagged only marks the release pull request as tagged, the release exists already
+	ReleaseMarkTagged
+)
+
+// DecideRelease decides whether the version of the merged release pull request has to be released. The
+// labels of the pull request are authoritative, a pull request without them, e.g. one created before the
+// labels were introduced, or a version without pull request is released if its tag doesn't exist.
+func DecideRelease(pullRequest *changelog.PullRequest, releaseExists bool) ReleaseAction {
+	switch {
+	case pullRequest != nil && helpers.Contains(pullRequest.Labels, naming.LabelTagged):
+		return ReleaseNone
+	case pullRequest != nil && helpers.Contains(pullRequest.Labels, naming.LabelPending) && releaseExists:
+		return ReleaseMarkTagged
+	case pullRequest != nil && helpers.Contains(pullRequest.Labels, naming.LabelPending):
+		return ReleaseCreate
+	case releaseExists:
+		return ReleaseNone
+	}
+	return ReleaseCreate
+}
@@ -0,0 +1,33 @@
+package git
+
+import (
+	"github.com/git-releaser/git-releaser/pkg/changelog"
+	"github.com/git-releaser/git-releaser/pkg/naming"
+	"testing"
+)
+
+func TestDecideRelease(t *testing.T) {
+	tests := []struct {
+		name          string
+		pullRequest   *changelog.PullRequest
+		releaseExists bool
+		want          ReleaseAction
+	}{
+		{name: "pending without release", pullRequest: &changelog.PullRequest{Labels: []string{"release", naming.LabelPending}}, want: ReleaseCreate},
+		{name: "pending with release", pullRequest: &changelog.PullRequest{Labels: []string{naming.LabelPending}}, releaseExists: true, want: ReleaseMarkTagged},
+		{name: "tagged with release", pullRequest: &changelog.PullRequest{Labels: []string{naming.LabelTagged}}, releaseExists: true, want: ReleaseNone},
+		{name: "tagged without release", pullRequest: &changelog.PullRequest{Labels: []string{naming.LabelTagged}}, want: ReleaseNone},
+		{name: "unlabeled without release", pullRequest: &changelog.PullRequest{}, want: ReleaseCreate},
+		{name: "unlabeled with release", pullRequest: &changelog.PullRequest{}, releaseExists: true, want: ReleaseNone},
+		{name: "no pull request without release", want: ReleaseCreate},
+		{name: "no pull request with release", releaseExists: true, want: ReleaseNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DecideRelease(tt.pullRequest, tt.releaseExists); got != tt.want {
+				t.Errorf("DecideRelease() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
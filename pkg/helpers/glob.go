@@ -0,0 +1,140 @@
+package helpers
+
+import (
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/util"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// MatchGlob reports whether a slash-separated path matches the pattern. Besides the syntax of
+// path.Match, "**" matches any number of directories.
+func MatchGlob(pattern string, name string) bool {
+	return matchSegments(splitPath(pattern), splitPath(name))
+}
+
+// Glob returns the files of the filesystem matching any of the patterns but none of the excludes,
+// sorted by path. A directory matches all files below it, an exclude matching a directory excludes
+// all files below it. Patterns without glob characters are returned as they are, even if the file
+// does not exist, so missing files are reported when they are read.
+func Glob(fs billy.Filesystem, patterns []string, excludes []string) ([]string, error) {
+	found := make(map[string]struct{})
+
+	for _, pattern := range patterns {
+		pattern = cleanPath(pattern)
+
+		if !hasGlobCharacters(pattern) {
+			info, err := fs.Stat(pattern)
+			if err != nil || !info.IsDir() {
+				if !isExcluded(pattern, excludes) {
+					found[pattern] = struct{}{}
+				}
+				continue
+			}
+			pattern = path.Join(pattern, "**")
+		}
+
+		err := util.Walk(fs, staticPrefix(pattern), func(name string, info os.FileInfo, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+
+			name = cleanPath(filepath.ToSlash(name))
+			if info.IsDir() {
+				if info.Name() == ".git" {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			if MatchGlob(pattern, name) && !isExcluded(name, excludes) {
+				found[name] = struct{}{}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	files := make([]string, 0, len(found))
+	for name := range found {
+		files = append(files, name)
+	}
+	sort.Strings(files)
+
+	return files, nil
+}
+
+func isExcluded(name string, excludes []string) bool {
+	for _, exclude := range excludes {
+		exclude = cleanPath(exclude)
+		if MatchGlob(exclude, name) || MatchGlob(path.Join(exclude, "**"), name) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchSegments(pattern []string, name []string) bool {
+	if len(pattern) == 0 {
+		return len(name) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(name); i++ {
+			if matchSegments(pattern[1:], name[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(name) == 0 {
+		return false
+	}
+
+	matched, err := path.Match(pattern[0], name[0])
+	if err != nil || !matched {
+		return false
+	}
+	return matchSegments(pattern[1:], name[1:])
+}
+
+// staticPrefix returns the directory of a pattern before the first segment containing glob characters
+func staticPrefix(pattern string) string {
+	var prefix []string
+	for _, segment := range splitPath(pattern) {
+		if hasGlobCharacters(segment) {
+			break
+		}
+		prefix = append(prefix, segment)
+	}
+
+	if len(prefix) == 0 {
+		return "."
+	}
+	return strings.Join(prefix, "/")
+}
+
+func hasGlobCharacters(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[")
+}
+
+func cleanPath(name string) string {
+	return strings.TrimPrefix(path.Clean(filepath.ToSlash(name)), "/")
+}
+
+func splitPath(name string) []string {
+	name = cleanPath(name)
+	if name == "." {
+		return nil
+	}
+	return strings.Split(name, "/")
+}
@@ -0,0 +1,81 @@
+package helpers
+
+import (
+	"github.com/go-git/go-billy/v5/memfs"
+	"reflect"
+	"testing"
+)
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{pattern: "values.yaml", name: "values.yaml", want: true},
+		{pattern: "deploy/values*.yaml", name: "deploy/values-prod.yaml", want: true},
+		{pattern: "deploy/values*.yaml", name: "deploy/prod/values.yaml", want: false},
+		{pattern: "deploy/**/values*.yaml", name: "deploy/values.yaml", want: true},
+		{pattern: "deploy/**/values*.yaml", name: "deploy/a/b/values-dev.yaml", want: true},
+		{pattern: "**/Chart.yaml", name: "charts/app/Chart.yaml", want: true},
+		{pattern: "./deploy/*.yaml", name: "deploy/app.yaml", want: true},
+	}
+	for _, tt := range tests {
+		if got := MatchGlob(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("MatchGlob(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestGlob(t *testing.T) {
+	fs := memfs.New()
+	for _, name := range []string{
+		"deploy/values.yaml",
+		"deploy/dev/values-dev.yaml",
+		"deploy/prod/values-prod.yaml",
+		"deploy/legacy/values.yaml",
+		"deploy/prod/secrets.yaml",
+		"README.md",
+	} {
+		file, err := fs.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		file.Close()
+	}
+
+	tests := []struct {
+		name     string
+		patterns []string
+		excludes []string
+		want     []string
+	}{
+		{
+			name:     "recursive pattern with exclusion",
+			patterns: []string{"deploy/**/values*.yaml"},
+			excludes: []string{"deploy/legacy"},
+			want:     []string{"deploy/dev/values-dev.yaml", "deploy/prod/values-prod.yaml", "deploy/values.yaml"},
+		},
+		{
+			name:     "directory",
+			patterns: []string{"deploy/prod"},
+			want:     []string{"deploy/prod/secrets.yaml", "deploy/prod/values-prod.yaml"},
+		},
+		{
+			name:     "literal path",
+			patterns: []string{"missing.yaml"},
+			want:     []string{"missing.yaml"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Glob(fs, tt.patterns, tt.excludes)
+			if err != nil {
+				t.Fatalf("Glob() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Glob() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
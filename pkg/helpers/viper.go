@@ -8,9 +8,11 @@ import (
 )
 
 func BindViperFlags(cmd *cobra.Command, v *viper.Viper) {
-	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+	bind := func(f *pflag.Flag) {
 		if err := v.BindPFlag(f.Name, f); err != nil {
 			fmt.Printf("Error binding flag '%s': %v\n", f.Name, err)
 		}
-	})
+	}
+	cmd.Flags().VisitAll(bind)
+	cmd.PersistentFlags().VisitAll(bind)
 }
@@ -1,8 +1,14 @@
 package naming
 
 import (
+	"bytes"
+	"crypto/sha1"
 	"fmt"
+	"github.com/Masterminds/semver"
 	"github.com/git-releaser/git-releaser/pkg/config"
+	"sort"
+	"strings"
+	"text/template"
 )
 
 const EnvPrefix = "GIT_RELEASER"
@@ -10,6 +16,12 @@ const DefaultConfigFileName = ".git-releaser-config"
 
 var DefaultManifestFileName = ".git-releaser-manifest.json"
 
+// Labels of the release pull requests, pending until the release of the merged pull request has been created
+const (
+	LabelPending = "autorelease: pending"
+	LabelTagged  = "autorelease: tagged"
+)
+
 func GeneratePrTitle(version string) string {
 	title := fmt.Sprintf("Release %s", version)
 	return title
@@ -49,3 +61,75 @@ func CreateBranchName(prefix string, version string) string {
 	}
 	return fmt.Sprintf("%s-%s", prefix, version)
 }
+
+// CreateFileUpdateBranchName returns the branch for updating tagged lines. Several replacements get a
+// name derived from their tags and values, so running the same update again reuses the branch.
+func CreateFileUpdateBranchName(replacements []config.TagReplacement) string {
+	if len(replacements) == 1 {
+		return fmt.Sprintf("release/replace-%s-%s", replacements[0].SearchTag, replacements[0].Value)
+	}
+
+	pairs := make([]string, len(replacements))
+	for i, replacement := range replacements {
+		pairs[i] = replacement.SearchTag + "=" + replacement.Value
+	}
+	sort.Strings(pairs)
+
+	sum := sha1.Sum([]byte(strings.Join(pairs, "\n")))
+	return fmt.Sprintf("release/replace-%x", sum[:4])
+}
+
+func CreateFileUpdateTitle(replacements []config.TagReplacement) string {
+	if len(replacements) == 1 {
+		return fmt.Sprintf("Update %s to %s", replacements[0].SearchTag, replacements[0].Value)
+	}
+	return fmt.Sprintf("Update %d versions", len(replacements))
+}
+
+func CreateFileUpdateDescription(replacements []config.TagReplacement) string {
+	description := "This pull request updates the following tagged versions.\n\n| Tag | Version | Files |\n|-----|---------|-------|\n"
+	for _, replacement := range replacements {
+		files := "`" + strings.Join(replacement.Files, "`, `") + "`"
+		if len(replacement.Exclude) > 0 {
+			files += " (excluding `" + strings.Join(replacement.Exclude, "`, `") + "`)"
+		}
+		description += fmt.Sprintf("| %s | %s | %s |\n", replacement.SearchTag, replacement.Value, files)
+	}
+	return description
+}
+
+// milestoneVersion holds the fields of a version which can be used in a milestone template, the same as in the
+// replacement of extra files
+type milestoneVersion struct {
+	Version    string
+	Original   string
+	Major      int64
+	Minor      int64
+	Patch      int64
+	Prerelease string
+	Metadata   string
+}
+
+// CreateMilestoneTitle renders the milestone template for a version
+func CreateMilestoneTitle(milestone string, version semver.Version) (string, error) {
+	tmpl, err := template.New("milestone").Option("missingkey=error").Parse(milestone)
+	if err != nil {
+		return "", err
+	}
+
+	var title bytes.Buffer
+	err = tmpl.Execute(&title, milestoneVersion{
+		Version:    version.String(),
+		Original:   version.Original(),
+		Major:      version.Major(),
+		Minor:      version.Minor(),
+		Patch:      version.Patch(),
+		Prerelease: version.Prerelease(),
+		Metadata:   version.Metadata(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return title.String(), nil
+}
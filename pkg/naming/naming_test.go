@@ -1,6 +1,9 @@
 package naming
 
 import (
+	"github.com/Masterminds/semver"
+	"github.com/git-releaser/git-releaser/pkg/config"
+	"strings"
 	"testing"
 )
 
@@ -42,3 +45,63 @@ func TestGeneratePrTitle(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateFileUpdateBranchName(t *testing.T) {
+	a := config.TagReplacement{SearchTag: "service-a", Value: "1.2.3", Files: []string{"a.yaml"}}
+	b := config.TagReplacement{SearchTag: "service-b", Value: "2.0.0", Files: []string{"b.yaml"}}
+
+	if got := CreateFileUpdateBranchName([]config.TagReplacement{a}); got != "release/replace-service-a-1.2.3" {
+		t.Errorf("CreateFileUpdateBranchName() = %v, want %v", got, "release/replace-service-a-1.2.3")
+	}
+
+	first := CreateFileUpdateBranchName([]config.TagReplacement{a, b})
+	second := CreateFileUpdateBranchName([]config.TagReplacement{b, a})
+	if first != second || !strings.HasPrefix(first, "release/replace-") || len(first) != len("release/replace-")+8 {
+		t.Errorf("CreateFileUpdateBranchName() = %v and %v, want the same short name", first, second)
+	}
+
+	b.Value = "2.0.1"
+	if got := CreateFileUpdateBranchName([]config.TagReplacement{a, b}); got == first {
+		t.Errorf("CreateFileUpdateBranchName() = %v for different values", got)
+	}
+}
+
+func TestCreateFileUpdateDescription(t *testing.T) {
+	replacements := []config.TagReplacement{
+		{SearchTag: "service-a", Value: "1.2.3", Files: []string{"deploy/**/values.yaml"}},
+		{SearchTag: "service-b", Value: "2.0.0", Files: []string{"deploy/b"}, Exclude: []string{"deploy/b/legacy"}},
+	}
+
+	expected := "This pull request updates the following tagged versions.\n\n" +
+		"| Tag | Version | Files |\n|-----|---------|-------|\n" +
+		"| service-a | 1.2.3 | `deploy/**/values.yaml` |\n" +
+		"| service-b | 2.0.0 | `deploy/b` (excluding `deploy/b/legacy`) |\n"
+
+	if result := CreateFileUpdateDescription(replacements); result != expected {
+		t.Errorf("Unexpected result: got %v, want %v", result, expected)
+	}
+}
+
+func TestCreateMilestoneTitle(t *testing.T) {
+	tests := []struct {
+		milestone string
+		want      string
+		wantErr   bool
+	}{
+		{milestone: "{{.Original}}", want: "v1.4.0"},
+		{milestone: "{{.Version}}", want: "1.4.0"},
+		{milestone: "Release {{.Major}}.{{.Minor}}", want: "Release 1.4"},
+		{milestone: "Sprint 12", want: "Sprint 12"},
+		{milestone: "{{.Unknown}}", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := CreateMilestoneTitle(tt.milestone, *semver.MustParse("v1.4.0"))
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("CreateMilestoneTitle(%q) error = %v, wantErr %v", tt.milestone, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("CreateMilestoneTitle(%q) = %q, want %q", tt.milestone, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package signing
+
+import (
+	"bytes"
+	"errors"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"io"
+)
+
+// OpenPGPSigner creates armored detached OpenPGP signatures
+type OpenPGPSigner struct {
+	Entity *openpgp.Entity
+}
+
+func newOpenPGPSigner(key []byte, passphrase []byte) (*OpenPGPSigner, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(key))
+	if err != nil {
+		return nil, err
+	}
+	if len(entities) == 0 || entities[0].PrivateKey == nil {
+		return nil, errors.New("the OpenPGP key contains no private key")
+	}
+
+	entity := entities[0]
+	if entity.PrivateKey.Encrypted {
+		if len(passphrase) == 0 {
+			return nil, errors.New("the OpenPGP key is encrypted, but no passphrase is configured")
+		}
+		err = entity.DecryptPrivateKeys(passphrase)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &OpenPGPSigner{Entity: entity}, nil
+}
+
+func (s *OpenPGPSigner) Sign(message io.Reader) ([]byte, error) {
+	var signature bytes.Buffer
+	err := openpgp.ArmoredDetachSign(&signature, s.Entity, message, nil)
+	if err != nil {
+		return nil, err
+	}
+	return signature.Bytes(), nil
+}
+
+func (s *OpenPGPSigner) Extension() string {
+	return ".asc"
+}
@@ -0,0 +1,143 @@
+package signing
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/pem"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"golang.org/x/crypto/ssh"
+	"strings"
+	"testing"
+)
+
+func TestOpenPGPSigner(t *testing.T) {
+	entity, err := openpgp.NewEntity("git-releaser", "", "releaser@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var key bytes.Buffer
+	writer, err := armor.Encode(&key, openpgp.PrivateKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := entity.SerializePrivate(writer, nil); err != nil {
+		t.Fatal(err)
+	}
+	writer.Close()
+
+	signer, err := NewSigner(key.Bytes(), nil, NamespaceFile)
+	if err != nil {
+		t.Fatalf("NewSigner() error = %v", err)
+	}
+	if signer.Extension() != ".asc" {
+		t.Errorf("Extension() = %v, want .asc", signer.Extension())
+	}
+
+	message := "checksums\n"
+	signature, err := signer.Sign(strings.NewReader(message))
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	_, err = openpgp.CheckArmoredDetachedSignature(openpgp.EntityList{entity}, strings.NewReader(message), bytes.NewReader(signature), nil)
+	if err != nil {
+		t.Errorf("CheckArmoredDetachedSignature() error = %v", err)
+	}
+}
+
+func TestSSHSigner(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := ssh.MarshalPrivateKey(privateKey, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := NewSigner(pem.EncodeToMemory(block), nil, NamespaceGit)
+	if err != nil {
+		t.Fatalf("NewSigner() error = %v", err)
+	}
+	if signer.Extension() != ".sig" {
+		t.Errorf("Extension() = %v, want .sig", signer.Extension())
+	}
+
+	message := "tree 1234\n"
+	armored, err := signer.Sign(strings.NewReader(message))
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	text := string(armored)
+	if !strings.HasPrefix(text, "-----BEGIN SSH SIGNATURE-----\n") || !strings.HasSuffix(text, "-----END SSH SIGNATURE-----\n") {
+		t.Fatalf("Sign() = %q, want an armored SSH signature", text)
+	}
+	encoded := strings.ReplaceAll(strings.TrimSuffix(strings.TrimPrefix(text, "-----BEGIN SSH SIGNATURE-----\n"), "-----END SSH SIGNATURE-----\n"), "\n", "")
+	blob, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sig struct {
+		Magic         [6]byte
+		Version       uint32
+		PublicKey     string
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     string
+	}
+	if err := ssh.Unmarshal(blob, &sig); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if string(sig.Magic[:]) != "SSHSIG" || sig.Version != 1 || sig.Namespace != NamespaceGit || sig.HashAlgorithm != "sha512" {
+		t.Fatalf("unexpected signature header %+v", sig)
+	}
+
+	publicKey, err := ssh.ParsePublicKey([]byte(sig.PublicKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var signature ssh.Signature
+	if err := ssh.Unmarshal([]byte(sig.Signature), &signature); err != nil {
+		t.Fatal(err)
+	}
+
+	hash := sha512.Sum512([]byte(message))
+	signedData := ssh.Marshal(struct {
+		Magic         [6]byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          string
+	}{[6]byte([]byte("SSHSIG")), NamespaceGit, "", "sha512", string(hash[:])})
+
+	if err := publicKey.Verify(signedData, &signature); err != nil {
+		t.Errorf("Verify() error = %v", err)
+	}
+}
+
+func TestNewSignerEncryptedKeyWithoutPassphrase(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := ssh.MarshalPrivateKeyWithPassphrase(privateKey, "", []byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := pem.EncodeToMemory(block)
+
+	if _, err := NewSigner(key, nil, NamespaceFile); err == nil {
+		t.Errorf("NewSigner() without passphrase succeeded for an encrypted key")
+	}
+	if _, err := NewSigner(key, []byte("secret"), NamespaceFile); err != nil {
+		t.Errorf("NewSigner() error = %v", err)
+	}
+}
@@ -0,0 +1,111 @@
+package signing
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"golang.org/x/crypto/ssh"
+	"io"
+	"strings"
+)
+
+const (
+	sshSignatureMagic   = "SSHSIG"
+	sshSignatureVersion = 1
+	sshHashAlgorithm    = "sha512"
+)
+
+// SSHSigner creates armored SSH signatures in the format of ssh-keygen -Y sign
+type SSHSigner struct {
+	Signer    ssh.Signer
+	Namespace string
+}
+
+func newSSHSigner(key []byte, passphrase []byte, namespace string) (*SSHSigner, error) {
+	signer, err := ssh.ParsePrivateKey(key)
+	var missingPassphrase *ssh.PassphraseMissingError
+	if errors.As(err, &missingPassphrase) {
+		if len(passphrase) == 0 {
+			return nil, errors.New("the SSH key is encrypted, but no passphrase is configured")
+		}
+		signer, err = ssh.ParsePrivateKeyWithPassphrase(key, passphrase)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &SSHSigner{Signer: signer, Namespace: namespace}, nil
+}
+
+func (s *SSHSigner) Sign(message io.Reader) ([]byte, error) {
+	hash := sha512.New()
+	if _, err := io.Copy(hash, message); err != nil {
+		return nil, err
+	}
+
+	// The signed data is described in https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig
+	signedData := ssh.Marshal(struct {
+		Magic         [6]byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          string
+	}{
+		Magic:         [6]byte([]byte(sshSignatureMagic)),
+		Namespace:     s.Namespace,
+		HashAlgorithm: sshHashAlgorithm,
+		Hash:          string(hash.Sum(nil)),
+	})
+
+	var signature *ssh.Signature
+	var err error
+	if algorithmSigner, ok := s.Signer.(ssh.AlgorithmSigner); ok && s.Signer.PublicKey().Type() == ssh.KeyAlgoRSA {
+		// SHA-1 RSA signatures are not accepted for SSH signatures
+		signature, err = algorithmSigner.SignWithAlgorithm(rand.Reader, signedData, ssh.KeyAlgoRSASHA512)
+	} else {
+		signature, err = s.Signer.Sign(rand.Reader, signedData)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	blob := ssh.Marshal(struct {
+		Magic         [6]byte
+		Version       uint32
+		PublicKey     string
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     string
+	}{
+		Magic:         [6]byte([]byte(sshSignatureMagic)),
+		Version:       sshSignatureVersion,
+		PublicKey:     string(s.Signer.PublicKey().Marshal()),
+		Namespace:     s.Namespace,
+		HashAlgorithm: sshHashAlgorithm,
+		Signature:     string(ssh.Marshal(signature)),
+	})
+
+	return armorSSHSignature(blob), nil
+}
+
+func (s *SSHSigner) Extension() string {
+	return ".sig"
+}
+
+// armorSSHSignature encodes a signature blob with base64 in lines of 70 characters
+func armorSSHSignature(blob []byte) []byte {
+	encoded := base64.StdEncoding.EncodeToString(blob)
+
+	var sb strings.Builder
+	sb.WriteString("-----BEGIN SSH SIGNATURE-----\n")
+	for len(encoded) > 70 {
+		sb.WriteString(encoded[:70] + "\n")
+		encoded = encoded[70:]
+	}
+	sb.WriteString(encoded + "\n")
+	sb.WriteString("-----END SSH SIGNATURE-----\n")
+
+	return []byte(sb.String())
+}
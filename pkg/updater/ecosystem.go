@@ -0,0 +1,201 @@
+package updater
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	cargoNameRegex    = regexp.MustCompile(`^\s*name\s*=\s*"([^"]*)"`)
+	cargoVersionRegex = regexp.MustCompile(`^(\s*version\s*=\s*")([^"]*)(".*)$`)
+	// setupCfgDynamicVersionRegex matches versions in setup.cfg which are read from code or files
+	setupCfgDynamicVersionRegex = regexp.MustCompile(`(?m)^\s*version\s*=\s*(attr|file):`)
+)
+
+// updateHelm updates version and appVersion of a Chart.yaml
+func updateHelm(path string, readFile ReadFileFunc, value string) ([]FileUpdate, error) {
+	content, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	total := 0
+	for _, selector := range []string{"version", "appVersion"} {
+		var count int
+		content, count, err = YAML(content, selector, value)
+		if err != nil {
+			return nil, err
+		}
+		total += count
+	}
+
+	return []FileUpdate{{Path: path, Content: content, Count: total}}, nil
+}
+
+// updateNpm updates the version of a package.json and of the package-lock.json next to it
+func updateNpm(path string, readFile ReadFileFunc, value string) ([]FileUpdate, error) {
+	content, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	content, count, err := JSON(content, "$.version", value)
+	if err != nil {
+		return nil, err
+	}
+	updates := []FileUpdate{{Path: path, Content: content, Count: count}}
+
+	lockPath := filepath.Join(filepath.Dir(path), "package-lock.json")
+	lockContent, err := readFile(lockPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return updates, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	total := 0
+	for _, selector := range []string{`$.version`, `$.packages[""].version`} {
+		lockContent, count, err = JSON(lockContent, selector, value)
+		if err != nil {
+			return nil, err
+		}
+		total += count
+	}
+
+	return append(updates, FileUpdate{Path: lockPath, Content: lockContent, Count: total}), nil
+}
+
+// updateCargo updates the package version of a Cargo.toml and the entry of the package in the Cargo.lock next to it
+func updateCargo(path string, readFile ReadFileFunc, value string) ([]FileUpdate, error) {
+	content, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	name := ""
+	table := ""
+	var values tomlValues
+	for _, line := range strings.Split(string(content), "\n") {
+		if values.continues(line) {
+			continue
+		}
+		if match := tomlTableRegex.FindStringSubmatch(line); match != nil {
+			table = match[2]
+			continue
+		}
+		if match := cargoNameRegex.FindStringSubmatch(line); match != nil && table == "package" {
+			name = match[1]
+			break
+		}
+	}
+
+	content, count, err := TOML(content, "package.version", value)
+	if err != nil {
+		return nil, err
+	}
+	updates := []FileUpdate{{Path: path, Content: content, Count: count}}
+
+	lockPath := filepath.Join(filepath.Dir(path), "Cargo.lock")
+	lockContent, err := readFile(lockPath)
+	if errors.Is(err, fs.ErrNotExist) || name == "" {
+		return updates, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Only update the version of the [[package]] entry of this crate
+	lines := strings.Split(string(lockContent), "\n")
+	count = 0
+	matched := false
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "[[package]]" {
+			matched = false
+			continue
+		}
+		if match := cargoNameRegex.FindStringSubmatch(line); match != nil {
+			matched = match[1] == name
+			continue
+		}
+		if match := cargoVersionRegex.FindStringSubmatch(line); match != nil && matched {
+			lines[i] = match[1] + value + match[3]
+			count++
+			matched = false
+		}
+	}
+
+	return append(updates, FileUpdate{Path: lockPath, Content: []byte(strings.Join(lines, "\n")), Count: count}), nil
+}
+
+// updatePython updates the version in a pyproject.toml (PEP 621 or Poetry) or setup.cfg. If both
+// files exist next to each other, both are updated.
+func updatePython(path string, readFile ReadFileFunc, value string) ([]FileUpdate, error) {
+	dir := filepath.Dir(path)
+	paths := []string{path}
+	switch filepath.Base(path) {
+	case "pyproject.toml":
+		paths = append(paths, filepath.Join(dir, "setup.cfg"))
+	case "setup.cfg":
+		paths = append(paths, filepath.Join(dir, "pyproject.toml"))
+	default:
+		return nil, fmt.Errorf("python updater expects a pyproject.toml or setup.cfg, got %s", path)
+	}
+
+	var updates []FileUpdate
+	for i, p := range paths {
+		content, err := readFile(p)
+		if errors.Is(err, fs.ErrNotExist) && i > 0 {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		selectors := []string{"project.version", "tool.poetry.version"}
+		if filepath.Base(p) == "setup.cfg" {
+			// setup.cfg is an INI file, which is compatible with the TOML updater for simple key-value pairs
+			selectors = []string{"metadata.version"}
+			if setupCfgDynamicVersionRegex.Match(content) {
+				selectors = nil
+			}
+		}
+
+		total := 0
+		for _, selector := range selectors {
+			var count int
+			content, count, err = TOML(content, selector, value)
+			if err != nil {
+				return nil, err
+			}
+			total += count
+		}
+		updates = append(updates, FileUpdate{Path: p, Content: content, Count: total})
+	}
+
+	return updates, nil
+}
+
+// updateGo updates a string constant or variable in a Go file, e.g. `const Version = "1.0.0"`.
+// The selector is the name of the identifier and defaults to Version. A "v" prefix is kept.
+func updateGo(path string, selector string, readFile ReadFileFunc, value string) ([]FileUpdate, error) {
+	if selector == "" {
+		selector = "Version"
+	}
+
+	content, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	regex := regexp.MustCompile(`(?m)^(\s*(?:const\s+|var\s+)?` + regexp.QuoteMeta(selector) + `(?:\s+string)?\s*=\s*")(v?)([^"]*)(")`)
+
+	count := len(regex.FindAllIndex(content, -1))
+	content = regex.ReplaceAll(content, []byte("${1}${2}"+value+"${4}"))
+
+	return []FileUpdate{{Path: path, Content: content, Count: count}}, nil
+}
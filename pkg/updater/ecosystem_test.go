@@ -0,0 +1,111 @@
+package updater
+
+import (
+	"io/fs"
+	"testing"
+)
+
+func readFromMap(files map[string]string) ReadFileFunc {
+	return func(path string) ([]byte, error) {
+		content, exists := files[path]
+		if !exists {
+			return nil, fs.ErrNotExist
+		}
+		return []byte(content), nil
+	}
+}
+
+func TestUpdateEcosystems(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileType string
+		path     string
+		selector string
+		files    map[string]string
+		want     map[string]string
+	}{
+		{
+			name:     "helm",
+			fileType: "helm",
+			path:     "charts/app/Chart.yaml",
+			files: map[string]string{
+				"charts/app/Chart.yaml": "apiVersion: v2\nname: app\nversion: 1.0.0\nappVersion: \"1.0.0\"\n",
+			},
+			want: map[string]string{
+				"charts/app/Chart.yaml": "apiVersion: v2\nname: app\nversion: 1.1.0\nappVersion: \"1.1.0\"\n",
+			},
+		},
+		{
+			name:     "npm with lock file",
+			fileType: "npm",
+			path:     "package.json",
+			files: map[string]string{
+				"package.json":      "{\n  \"name\": \"app\",\n  \"version\": \"1.0.0\"\n}\n",
+				"package-lock.json": "{\n  \"name\": \"app\",\n  \"version\": \"1.0.0\",\n  \"packages\": {\n    \"\": {\n      \"version\": \"1.0.0\"\n    },\n    \"node_modules/a\": {\n      \"version\": \"1.0.0\"\n    }\n  }\n}\n",
+			},
+			want: map[string]string{
+				"package.json":      "{\n  \"name\": \"app\",\n  \"version\": \"1.1.0\"\n}\n",
+				"package-lock.json": "{\n  \"name\": \"app\",\n  \"version\": \"1.1.0\",\n  \"packages\": {\n    \"\": {\n      \"version\": \"1.1.0\"\n    },\n    \"node_modules/a\": {\n      \"version\": \"1.0.0\"\n    }\n  }\n}\n",
+			},
+		},
+		{
+			name:     "cargo with lock file",
+			fileType: "cargo",
+			path:     "Cargo.toml",
+			files: map[string]string{
+				"Cargo.toml": "[package]\nname = \"app\"\nversion = \"1.0.0\"\n\n[dependencies]\nserde = { version = \"1.0.0\" }\n",
+				"Cargo.lock": "[[package]]\nname = \"app\"\nversion = \"1.0.0\"\n\n[[package]]\nname = \"serde\"\nversion = \"1.0.0\"\n",
+			},
+			want: map[string]string{
+				"Cargo.toml": "[package]\nname = \"app\"\nversion = \"1.1.0\"\n\n[dependencies]\nserde = { version = \"1.0.0\" }\n",
+				"Cargo.lock": "[[package]]\nname = \"app\"\nversion = \"1.1.0\"\n\n[[package]]\nname = \"serde\"\nversion = \"1.0.0\"\n",
+			},
+		},
+		{
+			name:     "python with setup.cfg",
+			fileType: "python",
+			path:     "pyproject.toml",
+			files: map[string]string{
+				"pyproject.toml": "[tool.poetry]\nname = \"app\"\nversion = \"1.0.0\"\n",
+				"setup.cfg":      "[metadata]\nname = app\nversion = 1.0.0\n",
+			},
+			want: map[string]string{
+				"pyproject.toml": "[tool.poetry]\nname = \"app\"\nversion = \"1.1.0\"\n",
+				"setup.cfg":      "[metadata]\nname = app\nversion = 1.1.0\n",
+			},
+		},
+		{
+			name:     "go constant",
+			fileType: "go",
+			path:     "version.go",
+			files: map[string]string{
+				"version.go": "package app\n\n// Version of the app\nconst Version = \"v1.0.0\"\n",
+			},
+			want: map[string]string{
+				"version.go": "package app\n\n// Version of the app\nconst Version = \"v1.1.0\"\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updates, err := Update(tt.fileType, tt.path, tt.selector, readFromMap(tt.files), "1.1.0")
+			if err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+
+			if len(updates) != len(tt.want) {
+				t.Fatalf("Update() returned %d files, want %d", len(updates), len(tt.want))
+			}
+
+			for _, update := range updates {
+				if string(update.Content) != tt.want[update.Path] {
+					t.Errorf("Update() %s got:\n%s\nwant:\n%s", update.Path, update.Content, tt.want[update.Path])
+				}
+				if update.Count == 0 {
+					t.Errorf("Update() %s has no replacements", update.Path)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package updater
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/Masterminds/semver"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const TypeGoModule = "go-module"
+
+var (
+	goModuleRegex      = regexp.MustCompile(`(?m)^(\s*module\s+)("?)([^\s"]+)("?)`)
+	goMajorSuffixRegex = regexp.MustCompile(`/v([2-9]|[1-9][0-9]+)$`)
+
+	// Directives of go.mod, either on a single line or in a block
+	goModDirectiveRegex  = regexp.MustCompile(`^\s*(require|replace)\s+([^(\s].*)`)
+	goModBlockStartRegex = regexp.MustCompile(`^\s*(require|replace)\s*\(\s*$`)
+	goModBlockEndRegex   = regexp.MustCompile(`^\s*\)\s*$`)
+	goModRequireRegex    = regexp.MustCompile(`^(\s*)(\S+)(\s+)(\S+)(.*\n?)$`)
+	goModReplaceRegex    = regexp.MustCompile(`^(\s*)(\S+)(\s+[^=\s]+)?(\s*=>.*\n?)$`)
+)
+
+// GoModulePath returns the path of a Go module for a major version, e.g. example.com/app/v2 for
+// example.com/app and major version 2. Versions 0 and 1 have no suffix, gopkg.in paths are kept.
+func GoModulePath(modulePath string, major int64) string {
+	if strings.HasPrefix(modulePath, "gopkg.in/") {
+		return modulePath
+	}
+
+	base := goMajorSuffixRegex.ReplaceAllString(modulePath, "")
+	if major < 2 {
+		return base
+	}
+	return fmt.Sprintf("%s/v%d", base, major)
+}
+
+// UpdateGoModule moves the Go module of the go.mod at path to the major version of value. The module
+// line of go.mod is rewritten and so are the imports of the module in its Go files, which are found in
+// fsys and read with readFile. Directories of nested modules, vendor and testdata are skipped, and imports of
+// nested modules are kept as they have their own path. Nested modules which require or replace the module
+// are updated as well. If the module path doesn't change, nothing is updated.
+func UpdateGoModule(fsys fs.FS, goModPath string, readFile ReadFileFunc, value string) ([]FileUpdate, error) {
+	version, err := semver.NewVersion(value)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := readFile(goModPath)
+	if err != nil {
+		return nil, err
+	}
+
+	match := goModuleRegex.FindSubmatchIndex(content)
+	if match == nil {
+		return []FileUpdate{{Path: goModPath, Content: content}}, nil
+	}
+
+	oldPath := string(content[match[6]:match[7]])
+	newPath := GoModulePath(oldPath, version.Major())
+	if oldPath == newPath {
+		return []FileUpdate{{Path: goModPath, Content: content}}, nil
+	}
+
+	modified := append(append(append([]byte{}, content[:match[6]]...), newPath...), content[match[7]:]...)
+	updates := []FileUpdate{{Path: goModPath, Content: modified, Count: 1}}
+
+	// Nested modules keep their paths, so their imports are left alone. Nested modules which require or
+	// replace the module are moved to its new path along with their imports of it.
+	goFiles, nestedDirs, err := goModuleFiles(fsys, path.Dir(goModPath))
+	if err != nil {
+		return nil, err
+	}
+
+	var nestedModules []string
+	for _, dir := range nestedDirs {
+		nestedModPath := path.Join(dir, "go.mod")
+		nested, err := readFile(nestedModPath)
+		if err != nil {
+			return nil, err
+		}
+		if match := goModuleRegex.FindSubmatch(nested); match != nil {
+			nestedModules = append(nestedModules, string(match[3]))
+		}
+
+		nested, count := rewriteGoModDependency(nested, oldPath, newPath, "v"+version.String())
+		if count == 0 {
+			continue
+		}
+		updates = append(updates, FileUpdate{Path: nestedModPath, Content: nested, Count: count})
+
+		nestedFiles, _, err := goModuleFiles(fsys, dir)
+		if err != nil {
+			return nil, err
+		}
+		goFiles = append(goFiles, nestedFiles...)
+	}
+
+	for _, name := range goFiles {
+		source, err := readFile(name)
+		if err != nil {
+			return nil, err
+		}
+
+		source, count, err := rewriteGoImports(source, oldPath, newPath, nestedModules)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if count > 0 {
+			updates = append(updates, FileUpdate{Path: name, Content: source, Count: count})
+		}
+	}
+
+	return updates, nil
+}
+
+// goModuleFiles returns the Go files of the module in root and the directories of the nested modules
+func goModuleFiles(fsys fs.FS, root string) ([]string, []string, error) {
+	var goFiles, nestedDirs []string
+	err := fs.WalkDir(fsys, root, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if name == root {
+				return nil
+			}
+			if isIgnoredGoDirectory(entry.Name()) {
+				return fs.SkipDir
+			}
+			if _, err := fs.Stat(fsys, path.Join(name, "go.mod")); err == nil {
+				nestedDirs = append(nestedDirs, name)
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		if strings.HasSuffix(name, ".go") {
+			goFiles = append(goFiles, name)
+		}
+		return nil
+	})
+	return goFiles, nestedDirs, err
+}
+
+// rewriteGoModDependency moves the require and replace directives of oldPath in a go.mod to newPath. The
+// required version becomes version, the version of a replaced module is dropped as it belongs to the old path.
+func rewriteGoModDependency(content []byte, oldPath string, newPath string, version string) ([]byte, int) {
+	lines := strings.SplitAfter(string(content), "\n")
+	block, count := "", 0
+	for i, line := range lines {
+		directive, body := block, line
+		if block != "" && goModBlockEndRegex.MatchString(line) {
+			block = ""
+			continue
+		} else if match := goModBlockStartRegex.FindStringSubmatch(line); match != nil {
+			block = match[1]
+			continue
+		} else if match := goModDirectiveRegex.FindStringSubmatchIndex(line); block == "" && match != nil {
+			directive, body = line[match[2]:match[3]], line[match[4]:]
+		} else if block == "" {
+			continue
+		}
+
+		var rewritten string
+		switch directive {
+		case "require":
+			if match := goModRequireRegex.FindStringSubmatch(body); match != nil && match[2] == oldPath {
+				rewritten = match[1] + newPath + match[3] + version + match[5]
+			}
+		case "replace":
+			if match := goModReplaceRegex.FindStringSubmatch(body); match != nil && match[2] == oldPath {
+				rewritten = match[1] + newPath + match[4]
+			}
+		}
+		if rewritten != "" {
+			lines[i] = line[:len(line)-len(body)] + rewritten
+			count++
+		}
+	}
+	return []byte(strings.Join(lines, "")), count
+}
+
+// isIgnoredGoDirectory reports whether the go tool ignores the directory when building a module
+func isIgnoredGoDirectory(name string) bool {
+	return name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
+
+// rewriteGoImports replaces the imports of oldPath and its packages in a Go file with newPath. Imports of the
+// excluded modules and their packages are kept.
+func rewriteGoImports(content []byte, oldPath string, newPath string, excluded []string) ([]byte, int, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", content, parser.ImportsOnly)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var out bytes.Buffer
+	last, count := 0, 0
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !hasImportPrefix(importPath, oldPath) || isExcludedImport(importPath, excluded) {
+			continue
+		}
+
+		start := fset.Position(spec.Path.Pos()).Offset
+		end := fset.Position(spec.Path.End()).Offset
+		out.Write(content[last:start])
+		out.WriteString(strconv.Quote(newPath + strings.TrimPrefix(importPath, oldPath)))
+		last = end
+		count++
+	}
+
+	if count == 0 {
+		return content, 0, nil
+	}
+	out.Write(content[last:])
+
+	return out.Bytes(), count, nil
+}
+
+// isExcludedImport reports whether importPath belongs to one of the excluded modules
+func isExcludedImport(importPath string, excluded []string) bool {
+	for _, module := range excluded {
+		if hasImportPrefix(importPath, module) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasImportPrefix reports whether importPath is the module path or a package of the module
+func hasImportPrefix(importPath string, modulePath string) bool {
+	return importPath == modulePath || strings.HasPrefix(importPath, modulePath+"/")
+}
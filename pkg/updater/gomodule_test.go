@@ -0,0 +1,125 @@
+package updater
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+)
+
+func readFromFS(fsys fs.FS) ReadFileFunc {
+	return func(path string) ([]byte, error) {
+		return fs.ReadFile(fsys, path)
+	}
+}
+
+func TestGoModulePath(t *testing.T) {
+	tests := []struct {
+		path  string
+		major int64
+		want  string
+	}{
+		{path: "example.com/app", major: 1, want: "example.com/app"},
+		{path: "example.com/app", major: 2, want: "example.com/app/v2"},
+		{path: "example.com/app/v2", major: 3, want: "example.com/app/v3"},
+		{path: "example.com/app/v2", major: 2, want: "example.com/app/v2"},
+		{path: "example.com/app/v12", major: 13, want: "example.com/app/v13"},
+		{path: "example.com/app/v1", major: 2, want: "example.com/app/v1/v2"},
+		{path: "gopkg.in/yaml.v3", major: 4, want: "gopkg.in/yaml.v3"},
+	}
+
+	for _, tt := range tests {
+		if got := GoModulePath(tt.path, tt.major); got != tt.want {
+			t.Errorf("GoModulePath(%q, %d) = %q, want %q", tt.path, tt.major, got, tt.want)
+		}
+	}
+}
+
+func TestUpdateGoModule(t *testing.T) {
+	files := fstest.MapFS{
+		"go.mod":           {Data: []byte("module example.com/app\n\ngo 1.21\n\nrequire example.com/application v1.0.0\n")},
+		"main.go":          {Data: []byte("package main\n\nimport (\n\t\"fmt\"\n\n\t\"example.com/app/pkg/a\"\n\tb \"example.com/app/pkg/b\"\n\t\"example.com/application\"\n)\n\nfunc main() { fmt.Println(\"example.com/app/pkg/a\") }\n")},
+		"pkg/a/a.go":       {Data: []byte("package a\n\nimport \"example.com/app\"\n")},
+		"pkg/b/b.go":       {Data: []byte("package b\n")},
+		"vendor/x/x.go":    {Data: []byte("package x\n\nimport \"example.com/app/pkg/a\"\n")},
+		"tools/go.mod":     {Data: []byte("module example.com/app/tools\n")},
+		"tools/tools.go":   {Data: []byte("package tools\n\nimport \"example.com/app/pkg/a\"\n")},
+		"docs/README.md":   {Data: []byte("example.com/app/pkg/a\n")},
+		"cmd/gen.go":       {Data: []byte("package cmd\n\nimport (\n\t\"example.com/app/pkg/a\"\n\t\"example.com/app/tools\"\n\t\"example.com/app/tools/gen\"\n)\n")},
+		"cmd/tools.go":     {Data: []byte("package cmd\n\nimport \"example.com/app/tools\"\n")},
+		"pkg/a/a_test.go":  {Data: []byte("package a_test\n\nimport \"example.com/app/pkg/a\"\n")},
+		"testdata/data.go": {Data: []byte("package data\n\nimport \"example.com/app/pkg/a\"\n")},
+		// A nested module which depends on the root module
+		"plugin/go.mod":        {Data: []byte("module example.com/app/plugin\n\nrequire (\n\texample.com/app v1.4.0\n\texample.com/application v1.0.0\n)\n\nreplace example.com/app v1.4.0 => ../\n")},
+		"plugin/plugin.go":     {Data: []byte("package plugin\n\nimport (\n\t\"example.com/app/pkg/a\"\n\t\"example.com/app/plugin/internal\"\n)\n")},
+		"plugin/internal/x.go": {Data: []byte("package internal\n\nimport \"example.com/app\"\n")},
+	}
+
+	updates, err := UpdateGoModule(files, "go.mod", readFromFS(files), "2.0.0")
+	if err != nil {
+		t.Fatalf("UpdateGoModule() error = %v", err)
+	}
+
+	want := map[string]string{
+		"go.mod":               "module example.com/app/v2\n\ngo 1.21\n\nrequire example.com/application v1.0.0\n",
+		"main.go":              "package main\n\nimport (\n\t\"fmt\"\n\n\t\"example.com/app/v2/pkg/a\"\n\tb \"example.com/app/v2/pkg/b\"\n\t\"example.com/application\"\n)\n\nfunc main() { fmt.Println(\"example.com/app/pkg/a\") }\n",
+		"pkg/a/a.go":           "package a\n\nimport \"example.com/app/v2\"\n",
+		"pkg/a/a_test.go":      "package a_test\n\nimport \"example.com/app/v2/pkg/a\"\n",
+		"cmd/gen.go":           "package cmd\n\nimport (\n\t\"example.com/app/v2/pkg/a\"\n\t\"example.com/app/tools\"\n\t\"example.com/app/tools/gen\"\n)\n",
+		"plugin/go.mod":        "module example.com/app/plugin\n\nrequire (\n\texample.com/app/v2 v2.0.0\n\texample.com/application v1.0.0\n)\n\nreplace example.com/app/v2 => ../\n",
+		"plugin/plugin.go":     "package plugin\n\nimport (\n\t\"example.com/app/v2/pkg/a\"\n\t\"example.com/app/plugin/internal\"\n)\n",
+		"plugin/internal/x.go": "package internal\n\nimport \"example.com/app/v2\"\n",
+	}
+
+	if len(updates) != len(want) {
+		t.Fatalf("UpdateGoModule() updated %d files, want %d: %v", len(updates), len(want), updates)
+	}
+	for _, update := range updates {
+		if string(update.Content) != want[update.Path] {
+			t.Errorf("UpdateGoModule() %s = %q, want %q", update.Path, update.Content, want[update.Path])
+		}
+	}
+
+	updates, err = UpdateGoModule(files, "go.mod", readFromFS(files), "1.4.0")
+	if err != nil {
+		t.Fatalf("UpdateGoModule() error = %v", err)
+	}
+	if len(updates) != 1 || updates[0].Count != 0 {
+		t.Errorf("UpdateGoModule() without major version change = %v, want no changes", updates)
+	}
+}
+
+func TestRewriteGoModDependency(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		count   int
+	}{
+		{
+			name:    "single line require",
+			content: "module example.com/app/tools\n\nrequire example.com/app v1.4.0 // indirect\n",
+			want:    "module example.com/app/tools\n\nrequire example.com/app/v2 v2.0.0 // indirect\n",
+			count:   1,
+		},
+		{
+			name:    "replace block",
+			content: "replace (\n\texample.com/app => ../\n\texample.com/application => ../../application\n)\n",
+			want:    "replace (\n\texample.com/app/v2 => ../\n\texample.com/application => ../../application\n)\n",
+			count:   1,
+		},
+		{
+			name:    "other modules",
+			content: "require example.com/application v1.0.0\n\nreplace example.com/app/tools => ./tools\n",
+			want:    "require example.com/application v1.0.0\n\nreplace example.com/app/tools => ./tools\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, count := rewriteGoModDependency([]byte(tt.content), "example.com/app", "example.com/app/v2", "v2.0.0")
+			if string(got) != tt.want || count != tt.count {
+				t.Errorf("rewriteGoModDependency() = %q, %d, want %q, %d", got, count, tt.want, tt.count)
+			}
+		})
+	}
+}
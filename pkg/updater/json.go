@@ -0,0 +1,114 @@
+package updater
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// JSON replaces the scalar value at the selector of a JSON document, keeping the rest of the document untouched
+func JSON(content []byte, selector string, value string) ([]byte, int, error) {
+	path, err := parseSelector(selector)
+	if err != nil {
+		return content, 0, err
+	}
+
+	l := jsonLocator{content: content, decoder: json.NewDecoder(bytes.NewReader(content))}
+	l.decoder.UseNumber()
+
+	err = l.value(path, true)
+	if err != nil {
+		return content, 0, fmt.Errorf("could not parse JSON: %w", err)
+	}
+
+	if !l.found {
+		return content, 0, nil
+	}
+
+	// Versions are strings, even if the current value is a number
+	replacement, err := json.Marshal(value)
+	if err != nil {
+		return content, 0, err
+	}
+
+	return replaceRange(content, l.start, l.end, string(replacement)), 1, nil
+}
+
+// jsonLocator finds the byte range of the value at a path while decoding a JSON document
+type jsonLocator struct {
+	content    []byte
+	decoder    *json.Decoder
+	found      bool
+	start, end int
+}
+
+// value reads the next value of the document. If match is set, path is the remaining path of the
+// selector below this value.
+func (l *jsonLocator) value(path []pathElement, match bool) error {
+	start := l.skipSeparators(int(l.decoder.InputOffset()))
+
+	token, err := l.decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		for l.decoder.More() {
+			key, err := l.decoder.Token()
+			if err != nil {
+				return err
+			}
+			matchKey := match && len(path) > 0 && !path[0].IsIndex && path[0].Key == key
+			if err := l.value(tail(path), matchKey); err != nil {
+				return err
+			}
+		}
+		_, err = l.decoder.Token()
+		return err
+	case json.Delim('['):
+		for index := 0; l.decoder.More(); index++ {
+			matchIndex := match && len(path) > 0 && path[0].IsIndex && path[0].Index == index
+			if err := l.value(tail(path), matchIndex); err != nil {
+				return err
+			}
+		}
+		_, err = l.decoder.Token()
+		return err
+	default:
+		if match && len(path) == 0 && !l.found {
+			l.found = true
+			l.start = start
+			l.end = int(l.decoder.InputOffset())
+		}
+		return nil
+	}
+}
+
+// skipSeparators returns the offset of the next value, skipping whitespace and separators
+func (l *jsonLocator) skipSeparators(offset int) int {
+	for offset < len(l.content) {
+		switch l.content[offset] {
+		case ' ', '\t', '\r', '\n', ':', ',':
+			offset++
+		default:
+			return offset
+		}
+	}
+	return offset
+}
+
+func tail(path []pathElement) []pathElement {
+	if len(path) == 0 {
+		return nil
+	}
+	return path[1:]
+}
+
+func replaceRange(content []byte, start int, end int, replacement string) []byte {
+	var buffer bytes.Buffer
+	buffer.Write(content[:start])
+	buffer.WriteString(replacement)
+	buffer.Write(content[end:])
+	return buffer.Bytes()
+}
@@ -0,0 +1,150 @@
+package updater
+
+import (
+	"fmt"
+	"github.com/Masterminds/semver"
+	"regexp"
+	"strings"
+)
+
+var (
+	// annotationRegex matches lines annotated with "# x-git-releaser-version" and an optional format
+	annotationRegex = regexp.MustCompile(`(?m)^(.*?)# x-git-releaser-version(?::([\w.-]+))?(.*)$`)
+	// blockRegex matches the text between the start and end markers, the start marker may contain a format
+	blockRegex = regexp.MustCompile(`(?s)(<!-- x-git-releaser-version-start(?::([\w.-]+))? -->)(.*?)(<!-- x-git-releaser-version-end -->)`)
+
+	// The versions to replace for each number of version components. Versions must not be part of a
+	// word or a longer version, the "v" prefix is captured separately.
+	fullVersionRegex       = regexp.MustCompile(`(^|[^\w.])(v?)(\d+\.\d+\.\d+(?:-[0-9A-Za-z.-]+)?(?:\+[0-9A-Za-z.-]+)?)`)
+	majorMinorVersionRegex = regexp.MustCompile(`(^|[^\w.])(v?)(\d+\.\d+)(?:[^\w.]|$)`)
+	majorVersionRegex      = regexp.MustCompile(`(^|[^\w.])(v?)(\d+)(?:[^\w.]|$)`)
+)
+
+// versionFormat describes how a version is written in an annotated line
+type versionFormat struct {
+	components int
+	// prefix forces a "v" prefix, otherwise the prefix of the existing version is kept
+	prefix bool
+}
+
+// versionFormats are the formats an annotation can carry, the default keeps the existing "v" prefix
+var versionFormats = map[string]versionFormat{
+	"":              {components: 3},
+	"full":          {components: 3},
+	"major.minor":   {components: 2},
+	"major":         {components: 1},
+	"v-prefixed":    {components: 3, prefix: true},
+	"v-major.minor": {components: 2, prefix: true},
+	"v-major":       {components: 1, prefix: true},
+}
+
+// parseVersionFormat parses the format of an annotation, e.g. "major.minor" or "v-prefixed"
+func parseVersionFormat(format string) (versionFormat, error) {
+	f, exists := versionFormats[format]
+	if !exists {
+		return f, fmt.Errorf("unknown version format %q", format)
+	}
+	return f, nil
+}
+
+func (f versionFormat) regex() *regexp.Regexp {
+	switch f.components {
+	case 1:
+		return majorVersionRegex
+	case 2:
+		return majorMinorVersionRegex
+	default:
+		return fullVersionRegex
+	}
+}
+
+func (f versionFormat) format(version semver.Version, existingPrefix string) string {
+	prefix := existingPrefix
+	if f.prefix {
+		prefix = "v"
+	}
+
+	switch f.components {
+	case 1:
+		return fmt.Sprintf("%s%d", prefix, version.Major())
+	case 2:
+		return fmt.Sprintf("%s%d.%d", prefix, version.Major(), version.Minor())
+	default:
+		return prefix + version.String()
+	}
+}
+
+// replaceFirstVersion replaces the first version of the given format in text
+func replaceFirstVersion(text string, format versionFormat, version semver.Version) (string, bool) {
+	match := format.regex().FindStringSubmatchIndex(text)
+	if match == nil {
+		return text, false
+	}
+
+	// match[4:6] is the "v" prefix, match[6:8] the version itself
+	prefix := text[match[4]:match[5]]
+	return text[:match[4]] + format.format(version, prefix) + text[match[7]:], true
+}
+
+// Annotations replaces the version in every line annotated with "# x-git-releaser-version". The
+// annotation may carry a format, e.g. "# x-git-releaser-version:major.minor" or ":v-prefixed".
+// It returns the modified content and the number of replaced versions.
+func Annotations(content []byte, version semver.Version) ([]byte, int, error) {
+	count := 0
+	var errs []string
+
+	modified := annotationRegex.ReplaceAllStringFunc(string(content), func(line string) string {
+		match := annotationRegex.FindStringSubmatch(line)
+
+		format, err := parseVersionFormat(match[2])
+		if err != nil {
+			errs = append(errs, err.Error())
+			return line
+		}
+
+		text, replaced := replaceFirstVersion(match[1], format, version)
+		if !replaced {
+			return line
+		}
+
+		count++
+		return text + line[len(match[1]):]
+	})
+
+	if len(errs) > 0 {
+		return content, 0, fmt.Errorf("%s", strings.Join(errs, ", "))
+	}
+	return []byte(modified), count, nil
+}
+
+// Blocks replaces the version between "<!-- x-git-releaser-version-start -->" and
+// "<!-- x-git-releaser-version-end -->". The start marker may carry a format like an annotation,
+// e.g. "<!-- x-git-releaser-version-start:major -->". It returns the modified content and the
+// number of replaced versions.
+func Blocks(content []byte, version semver.Version) ([]byte, int, error) {
+	count := 0
+	var errs []string
+
+	modified := blockRegex.ReplaceAllStringFunc(string(content), func(block string) string {
+		match := blockRegex.FindStringSubmatch(block)
+
+		format, err := parseVersionFormat(match[2])
+		if err != nil {
+			errs = append(errs, err.Error())
+			return block
+		}
+
+		text, replaced := replaceFirstVersion(match[3], format, version)
+		if !replaced {
+			return block
+		}
+
+		count++
+		return match[1] + text + match[4]
+	})
+
+	if len(errs) > 0 {
+		return content, 0, fmt.Errorf("%s", strings.Join(errs, ", "))
+	}
+	return []byte(modified), count, nil
+}
@@ -0,0 +1,109 @@
+package updater
+
+import (
+	"github.com/Masterminds/semver"
+	"testing"
+)
+
+func TestAnnotations(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		version   string
+		want      string
+		wantCount int
+		wantErr   bool
+	}{
+		{
+			name:      "default format",
+			content:   "my_version: 0.0.1 # x-git-releaser-version\nother_version: 0.0.2\n",
+			version:   "0.1.0",
+			want:      "my_version: 0.1.0 # x-git-releaser-version\nother_version: 0.0.2\n",
+			wantCount: 1,
+		},
+		{
+			name:      "keeps existing prefix",
+			content:   "image: app2:v1.2.3 # x-git-releaser-version\n",
+			version:   "1.3.0",
+			want:      "image: app2:v1.3.0 # x-git-releaser-version\n",
+			wantCount: 1,
+		},
+		{
+			name:      "prerelease and build metadata",
+			content:   "version: 1.2.3-rc.1+build.5 # x-git-releaser-version\n",
+			version:   "1.3.0-beta.2",
+			want:      "version: 1.3.0-beta.2 # x-git-releaser-version\n",
+			wantCount: 1,
+		},
+		{
+			name:      "v-prefixed",
+			content:   "image: app:1.2.3 # x-git-releaser-version:v-prefixed\n",
+			version:   "1.3.0",
+			want:      "image: app:v1.3.0 # x-git-releaser-version:v-prefixed\n",
+			wantCount: 1,
+		},
+		{
+			name:      "major.minor",
+			content:   "pin: 1.2 # x-git-releaser-version:major.minor\n",
+			version:   "1.3.4",
+			want:      "pin: 1.3 # x-git-releaser-version:major.minor\n",
+			wantCount: 1,
+		},
+		{
+			name:      "v-major",
+			content:   "uses: actions/foo@v1 # x-git-releaser-version:v-major\n",
+			version:   "2.0.0",
+			want:      "uses: actions/foo@v2 # x-git-releaser-version:v-major\n",
+			wantCount: 1,
+		},
+		{
+			name:      "no version of the format",
+			content:   "pin: 1.2 # x-git-releaser-version\n",
+			version:   "1.3.0",
+			want:      "pin: 1.2 # x-git-releaser-version\n",
+			wantCount: 0,
+		},
+		{
+			name:    "unknown format",
+			content: "pin: 1.2 # x-git-releaser-version:minor\n",
+			version: "1.3.0",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, count, err := Annotations([]byte(tt.content), *semver.MustParse(tt.version))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Annotations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if string(got) != tt.want {
+				t.Errorf("Annotations() got:\n%s\nwant:\n%s", got, tt.want)
+			}
+			if count != tt.wantCount {
+				t.Errorf("Annotations() count = %d, want %d", count, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestBlocks(t *testing.T) {
+	content := "Install it:\n<!-- x-git-releaser-version-start -->\n```\ngo install example.com/app@v1.2.3\n```\n<!-- x-git-releaser-version-end -->\n" +
+		"Docs for <!-- x-git-releaser-version-start:major.minor -->1.2<!-- x-git-releaser-version-end -->\n"
+	want := "Install it:\n<!-- x-git-releaser-version-start -->\n```\ngo install example.com/app@v1.3.0\n```\n<!-- x-git-releaser-version-end -->\n" +
+		"Docs for <!-- x-git-releaser-version-start:major.minor -->1.3<!-- x-git-releaser-version-end -->\n"
+
+	got, count, err := Blocks([]byte(content), *semver.MustParse("1.3.0"))
+	if err != nil {
+		t.Fatalf("Blocks() error = %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("Blocks() got:\n%s\nwant:\n%s", got, want)
+	}
+	if count != 2 {
+		t.Errorf("Blocks() count = %d, want 2", count)
+	}
+}
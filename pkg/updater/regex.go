@@ -0,0 +1,92 @@
+package updater
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/Masterminds/semver"
+	"regexp"
+	"text/template"
+)
+
+const defaultReplacement = "{{.Version}}"
+
+// templateVersion holds the components of a version which can be used in a replacement template
+type templateVersion struct {
+	Version    string
+	Original   string
+	Major      int64
+	Minor      int64
+	Patch      int64
+	Prerelease string
+	Metadata   string
+}
+
+// Regex replaces the named group "version" in every match of pattern with the rendered replacement,
+// keeping the rest of the match. The replacement is a text/template which can use {{.Version}},
+// {{.Original}}, {{.Major}}, {{.Minor}}, {{.Patch}}, {{.Prerelease}} and {{.Metadata}} and defaults
+// to {{.Version}}.
+func Regex(content []byte, pattern string, replacement string, version semver.Version) ([]byte, int, error) {
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		return content, 0, err
+	}
+
+	group := regex.SubexpIndex("version")
+	if group < 0 {
+		return content, 0, fmt.Errorf("regex %q has no named group (?P<version>...)", pattern)
+	}
+
+	value, err := renderReplacement(replacement, version)
+	if err != nil {
+		return content, 0, err
+	}
+
+	var out bytes.Buffer
+	last, count := 0, 0
+	for _, match := range regex.FindAllSubmatchIndex(content, -1) {
+		start, end := match[2*group], match[2*group+1]
+		if start < 0 {
+			continue
+		}
+
+		out.Write(content[last:start])
+		out.WriteString(value)
+		last = end
+		count++
+	}
+
+	if count == 0 {
+		return content, 0, nil
+	}
+	out.Write(content[last:])
+
+	return out.Bytes(), count, nil
+}
+
+// renderReplacement renders the replacement template for a version
+func renderReplacement(replacement string, version semver.Version) (string, error) {
+	if replacement == "" {
+		replacement = defaultReplacement
+	}
+
+	tmpl, err := template.New("replacement").Option("missingkey=error").Parse(replacement)
+	if err != nil {
+		return "", err
+	}
+
+	var value bytes.Buffer
+	err = tmpl.Execute(&value, templateVersion{
+		Version:    version.String(),
+		Original:   version.Original(),
+		Major:      version.Major(),
+		Minor:      version.Minor(),
+		Patch:      version.Patch(),
+		Prerelease: version.Prerelease(),
+		Metadata:   version.Metadata(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return value.String(), nil
+}
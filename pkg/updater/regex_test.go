@@ -0,0 +1,78 @@
+package updater
+
+import (
+	"github.com/Masterminds/semver"
+	"testing"
+)
+
+func TestRegex(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		pattern     string
+		replacement string
+		want        string
+		count       int
+		wantErr     bool
+	}{
+		{
+			name:    "default replacement",
+			content: "name: app\nappVersion: \"1.0.0\"\n",
+			pattern: `appVersion: "(?P<version>[^"]+)"`,
+			want:    "name: app\nappVersion: \"2.1.0-rc.1\"\n",
+			count:   1,
+		},
+		{
+			name:        "template with version components",
+			content:     "image: registry/app:v1.0\nother: registry/app:v1.0\n",
+			pattern:     `(?m)^image: registry/app:(?P<version>\S+)$`,
+			replacement: "v{{.Major}}.{{.Minor}}",
+			want:        "image: registry/app:v2.1\nother: registry/app:v1.0\n",
+			count:       1,
+		},
+		{
+			name:        "all matches",
+			content:     "a=1.0.0 b=1.0.0",
+			pattern:     `=(?P<version>[\d.]+)`,
+			replacement: "{{.Major}}.{{.Minor}}.{{.Patch}}{{if .Prerelease}}-{{.Prerelease}}{{end}}",
+			want:        "a=2.1.0-rc.1 b=2.1.0-rc.1",
+			count:       2,
+		},
+		{
+			name:    "no match",
+			content: "version: 1.0.0",
+			pattern: `appVersion: (?P<version>\S+)`,
+			want:    "version: 1.0.0",
+		},
+		{
+			name:    "missing version group",
+			content: "version: 1.0.0",
+			pattern: `version: (\S+)`,
+			wantErr: true,
+		},
+		{
+			name:        "unknown template field",
+			content:     "version: 1.0.0",
+			pattern:     `version: (?P<version>\S+)`,
+			replacement: "{{.Build}}",
+			wantErr:     true,
+		},
+	}
+
+	version := *semver.MustParse("v2.1.0-rc.1")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, count, err := Regex([]byte(tt.content), tt.pattern, tt.replacement, version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Regex() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if string(got) != tt.want || count != tt.count {
+				t.Errorf("Regex() = %q, %d, want %q, %d", got, count, tt.want, tt.count)
+			}
+		})
+	}
+}
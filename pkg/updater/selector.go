@@ -0,0 +1,64 @@
+package updater
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// pathElement is a single step of a selector, either a key of a map or an index of a list
+type pathElement struct {
+	Key     string
+	Index   int
+	IsIndex bool
+}
+
+// parseSelector parses path expressions like "$.version", "tool.poetry.version",
+// "packages[\"\"].version" or "spec.containers[0].image". The leading "$" is optional.
+func parseSelector(selector string) ([]pathElement, error) {
+	var path []pathElement
+
+	s := strings.TrimSpace(selector)
+	s = strings.TrimPrefix(s, "$")
+
+	for len(s) > 0 {
+		switch s[0] {
+		case '.':
+			s = s[1:]
+		case '[':
+			if len(s) > 1 && (s[1] == '"' || s[1] == '\'') {
+				// Quoted keys may contain dots and brackets, so the key ends at the closing quote
+				closing := strings.Index(s[2:], string(s[1])+"]")
+				if closing < 0 {
+					return nil, fmt.Errorf("invalid selector %q: missing %c]", selector, s[1])
+				}
+				path = append(path, pathElement{Key: s[2 : 2+closing]})
+				s = s[2+closing+2:]
+				continue
+			}
+
+			end := strings.Index(s, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("invalid selector %q: missing ]", selector)
+			}
+			index, err := strconv.Atoi(s[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid selector %q: %s is no index", selector, s[1:end])
+			}
+			path = append(path, pathElement{Index: index, IsIndex: true})
+			s = s[end+1:]
+		default:
+			end := strings.IndexAny(s, ".[")
+			if end < 0 {
+				end = len(s)
+			}
+			path = append(path, pathElement{Key: s[:end]})
+			s = s[end:]
+		}
+	}
+
+	if len(path) == 0 {
+		return nil, fmt.Errorf("invalid selector %q: empty path", selector)
+	}
+	return path, nil
+}
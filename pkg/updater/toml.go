@@ -0,0 +1,187 @@
+package updater
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	tomlTableRegex    = regexp.MustCompile(`^\s*(\[\[?)\s*(.+?)\s*\]\]?\s*(#.*)?$`)
+	tomlKeyValueRegex = regexp.MustCompile(`^(\s*)([A-Za-z0-9_\-."' ]+?)(\s*=\s*)("(?:[^"\\]|\\.)*"|'[^']*'|[^\s#]+)(.*)$`)
+)
+
+// TOML replaces the value of the key at the selector, e.g. "tool.poetry.version", keeping the
+// formatting of the file. Tables, dotted keys and quoted keys are supported, inline tables are not. The
+// entries of an array of tables are selected by their index, e.g. "bin[0].version". Multi-line arrays and
+// strings are skipped.
+func TOML(content []byte, selector string, value string) ([]byte, int, error) {
+	want, err := parseSelector(selector)
+	if err != nil {
+		return content, 0, err
+	}
+
+	count := 0
+	lines := strings.Split(string(content), "\n")
+	arrays := make(map[string]int)
+	var table []pathElement
+	var values tomlValues
+
+	for i, line := range lines {
+		if values.continues(line) {
+			continue
+		}
+
+		if match := tomlTableRegex.FindStringSubmatch(line); match != nil {
+			table = resolveTOMLTable(splitTOMLKey(match[2]), match[1] == "[[", arrays)
+			continue
+		}
+
+		match := tomlKeyValueRegex.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		key := append([]pathElement{}, table...)
+		for _, part := range splitTOMLKey(match[2]) {
+			key = append(key, pathElement{Key: part})
+		}
+		if !equalElements(key, want) {
+			continue
+		}
+
+		lines[i] = match[1] + match[2] + match[3] + formatTOMLValue(match[4], value) + match[5]
+		count++
+	}
+
+	return []byte(strings.Join(lines, "\n")), count, nil
+}
+
+// tomlValues keeps track of the arrays and multi-line strings which span several lines. Their lines are
+// neither table headers nor keys, e.g. ["a", "b"], in a nested array.
+type tomlValues struct {
+	brackets  int
+	multiline string
+}
+
+// continues reports whether the line belongs to a value started on a previous line and scans it for values
+// which are continued on the next line
+func (v *tomlValues) continues(line string) bool {
+	continued := v.brackets > 0 || v.multiline != ""
+
+	for i := 0; i < len(line); i++ {
+		if v.multiline != "" {
+			if strings.HasPrefix(line[i:], v.multiline) {
+				i += len(v.multiline) - 1
+				v.multiline = ""
+			} else if v.multiline == `"""` && line[i] == '\\' {
+				i++
+			}
+			continue
+		}
+
+		switch line[i] {
+		case '#':
+			return continued
+		case '"', '\'':
+			quote := line[i : i+1]
+			if strings.HasPrefix(line[i:], strings.Repeat(quote, 3)) {
+				v.multiline = strings.Repeat(quote, 3)
+				i += 2
+				continue
+			}
+			for i++; i < len(line) && line[i:i+1] != quote; i++ {
+				if quote == `"` && line[i] == '\\' {
+					i++
+				}
+			}
+		case '[':
+			v.brackets++
+		case ']':
+			if v.brackets > 0 {
+				v.brackets--
+			}
+		}
+	}
+	return continued
+}
+
+// resolveTOMLTable returns the path of a table header. A header of an array of tables adds an entry to the
+// array, with the number of entries kept in arrays, tables below an array belong to its last entry.
+func resolveTOMLTable(keys []string, isArray bool, arrays map[string]int) []pathElement {
+	var path []pathElement
+	for i, key := range keys {
+		path = append(path, pathElement{Key: key})
+		id := fmt.Sprint(path)
+
+		entries, exists := arrays[id]
+		switch {
+		case isArray && i == len(keys)-1:
+			arrays[id] = entries + 1
+			path = append(path, pathElement{Index: entries, IsIndex: true})
+		case exists:
+			path = append(path, pathElement{Index: entries - 1, IsIndex: true})
+		}
+	}
+	return path
+}
+
+// splitTOMLKey splits a dotted key into its parts, removing quotes
+func splitTOMLKey(key string) []string {
+	var parts []string
+	var current strings.Builder
+	var quote rune
+
+	for _, r := range key {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			current.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '.':
+			parts = append(parts, strings.TrimSpace(current.String()))
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	return append(parts, strings.TrimSpace(current.String()))
+}
+
+// formatTOMLValue formats value with the same quotes as the original value
+func formatTOMLValue(original string, value string) string {
+	switch {
+	case strings.HasPrefix(original, `"`):
+		return `"` + value + `"`
+	case strings.HasPrefix(original, "'"):
+		return "'" + value + "'"
+	default:
+		return value
+	}
+}
+
+func equalElements(a []pathElement, b []pathElement) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalPath(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
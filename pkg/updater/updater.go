@@ -0,0 +1,63 @@
+package updater
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FileUpdate is the new content of a file changed by an updater and the number of replaced values
+type FileUpdate struct {
+	Path    string
+	Content []byte
+	Count   int
+}
+
+// ReadFileFunc reads a file relative to the root of the repository
+type ReadFileFunc func(path string) ([]byte, error)
+
+// Apply replaces the value at the selector of a structured file of the given type (json, yaml, toml or xml)
+// and returns the modified content together with the number of replaced values
+func Apply(fileType string, content []byte, selector string, value string) ([]byte, int, error) {
+	switch strings.ToLower(fileType) {
+	case "json":
+		return JSON(content, selector, value)
+	case "yaml", "yml":
+		return YAML(content, selector, value)
+	case "toml":
+		return TOML(content, selector, value)
+	case "xml":
+		return XML(content, selector, value)
+	default:
+		return content, 0, fmt.Errorf("unknown file type %q", fileType)
+	}
+}
+
+// Update runs the updater of the given type for the file at path. Besides the structured file types
+// of Apply, the ecosystem types helm, npm, cargo, python and go are supported, which may also update
+// related files like lock files. All files which were read are returned, including unchanged ones.
+func Update(fileType string, path string, selector string, readFile ReadFileFunc, value string) ([]FileUpdate, error) {
+	switch strings.ToLower(fileType) {
+	case "helm":
+		return updateHelm(path, readFile, value)
+	case "npm":
+		return updateNpm(path, readFile, value)
+	case "cargo":
+		return updateCargo(path, readFile, value)
+	case "python":
+		return updatePython(path, readFile, value)
+	case "go":
+		return updateGo(path, selector, readFile, value)
+	}
+
+	content, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	content, count, err := Apply(fileType, content, selector, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return []FileUpdate{{Path: path, Content: content, Count: count}}, nil
+}
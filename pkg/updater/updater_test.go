@@ -0,0 +1,296 @@
+package updater
+
+import (
+	"testing"
+)
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name      string
+		fileType  string
+		content   string
+		selector  string
+		want      string
+		wantCount int
+	}{
+		{
+			name:     "json top level",
+			fileType: "json",
+			content: `{
+  "name": "app",
+  "version": "1.0.0",
+  "dependencies": {"version": "3.0.0"}
+}
+`,
+			selector: "$.version",
+			want: `{
+  "name": "app",
+  "version": "1.1.0",
+  "dependencies": {"version": "3.0.0"}
+}
+`,
+			wantCount: 1,
+		},
+		{
+			name:      "json nested with quoted key and index",
+			fileType:  "json",
+			content:   `{"packages": {"": {"version": "1.0.0"}, "node_modules/a": {"version": "2.0.0"}}, "list": [1, {"v": "x"}]}`,
+			selector:  `$.packages[""].version`,
+			want:      `{"packages": {"": {"version": "1.1.0"}, "node_modules/a": {"version": "2.0.0"}}, "list": [1, {"v": "x"}]}`,
+			wantCount: 1,
+		},
+		{
+			name:      "json index",
+			fileType:  "json",
+			content:   `{"list": [1, {"v": "x"}]}`,
+			selector:  `list[1].v`,
+			want:      `{"list": [1, {"v": "1.1.0"}]}`,
+			wantCount: 1,
+		},
+		{
+			name:      "json missing",
+			fileType:  "json",
+			content:   `{"name": "app"}`,
+			selector:  `$.version`,
+			want:      `{"name": "app"}`,
+			wantCount: 0,
+		},
+		{
+			name:     "yaml keeps comments and quotes",
+			fileType: "yaml",
+			content: `# chart
+apiVersion: v2
+version: 1.0.0 # chart version
+appVersion: "1.0.0"
+image:
+  tag: '1.0.0'
+`,
+			selector: "appVersion",
+			want: `# chart
+apiVersion: v2
+version: 1.0.0 # chart version
+appVersion: "1.1.0"
+image:
+  tag: '1.0.0'
+`,
+			wantCount: 1,
+		},
+		{
+			name:     "yaml nested in every document",
+			fileType: "yaml",
+			content: `image:
+  tag: 1.0.0
+---
+image:
+  tag: 1.0.0
+`,
+			selector: "image.tag",
+			want: `image:
+  tag: 1.1.0
+---
+image:
+  tag: 1.1.0
+`,
+			wantCount: 2,
+		},
+		{
+			name:     "toml table",
+			fileType: "toml",
+			content: `[tool.poetry]
+name = "app"
+version = "1.0.0"  # keep me
+
+[tool.other]
+version = "1.0.0"
+`,
+			selector: "tool.poetry.version",
+			want: `[tool.poetry]
+name = "app"
+version = "1.1.0"  # keep me
+
+[tool.other]
+version = "1.0.0"
+`,
+			wantCount: 1,
+		},
+		{
+			name:     "xml pom keeps the document",
+			fileType: "xml",
+			content: `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <!-- the parent has its own version -->
+  <parent>
+    <version>2.0.0</version>
+  </parent>
+  <artifactId>app</artifactId>
+  <version>1.0.0</version>
+</project>
+`,
+			selector: "/project/version",
+			want: `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <!-- the parent has its own version -->
+  <parent>
+    <version>2.0.0</version>
+  </parent>
+  <artifactId>app</artifactId>
+  <version>1.1.0</version>
+</project>
+`,
+			wantCount: 1,
+		},
+		{
+			name:     "xml csproj with multiple property groups",
+			fileType: "xml",
+			content: `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFramework>net8.0</TargetFramework>
+  </PropertyGroup>
+  <PropertyGroup Condition="'$(Configuration)' == 'Release'">
+    <Version> 1.0.0 </Version>
+  </PropertyGroup>
+</Project>`,
+			selector: "/Project/PropertyGroup/Version",
+			want: `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFramework>net8.0</TargetFramework>
+  </PropertyGroup>
+  <PropertyGroup Condition="'$(Configuration)' == 'Release'">
+    <Version> 1.1.0 </Version>
+  </PropertyGroup>
+</Project>`,
+			wantCount: 1,
+		},
+		{
+			name:     "toml dotted key",
+			fileType: "toml",
+			content: `[tool]
+poetry.version = '1.0.0'
+`,
+			selector: "tool.poetry.version",
+			want: `[tool]
+poetry.version = '1.1.0'
+`,
+			wantCount: 1,
+		},
+		{
+			name:      "json number",
+			fileType:  "json",
+			content:   `{"version": 1, "name": "app"}`,
+			selector:  "$.version",
+			want:      `{"version": "1.1.0", "name": "app"}`,
+			wantCount: 1,
+		},
+		{
+			name:     "toml array of tables",
+			fileType: "toml",
+			content: `[[bin]]
+name = "a"
+version = "1.0.0"
+
+[bin.metadata]
+version = "1.0.0"
+
+[[bin]]
+name = "b"
+version = "1.0.0"
+`,
+			selector: "bin[1].version",
+			want: `[[bin]]
+name = "a"
+version = "1.0.0"
+
+[bin.metadata]
+version = "1.0.0"
+
+[[bin]]
+name = "b"
+version = "1.1.0"
+`,
+			wantCount: 1,
+		},
+		{
+			name:     "toml table below an array of tables",
+			fileType: "toml",
+			content: `[[bin]]
+version = "1.0.0"
+
+[bin.metadata]
+version = "1.0.0"
+`,
+			selector: "bin[0].metadata.version",
+			want: `[[bin]]
+version = "1.0.0"
+
+[bin.metadata]
+version = "1.1.0"
+`,
+			wantCount: 1,
+		},
+		{
+			name:     "toml multi-line values",
+			fileType: "toml",
+			content: `[tool.app]
+matrix = [
+  ["a", "b"],
+  [
+    "c",
+  ],
+]
+notes = """
+[tool.app]
+version = "0.1.0"
+"""
+version = "1.0.0"
+`,
+			selector: "tool.app.version",
+			want: `[tool.app]
+matrix = [
+  ["a", "b"],
+  [
+    "c",
+  ],
+]
+notes = """
+[tool.app]
+version = "0.1.0"
+"""
+version = "1.1.0"
+`,
+			wantCount: 1,
+		},
+		{
+			name:     "toml array of tables without index",
+			fileType: "toml",
+			content: `[[bin]]
+version = "1.0.0"
+
+[[bin]]
+version = "1.0.0"
+`,
+			selector: "bin.version",
+			want: `[[bin]]
+version = "1.0.0"
+
+[[bin]]
+version = "1.0.0"
+`,
+			wantCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, count, err := Apply(tt.fileType, []byte(tt.content), tt.selector, "1.1.0")
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Apply() got:\n%s\nwant:\n%s", got, tt.want)
+			}
+			if count != tt.wantCount {
+				t.Errorf("Apply() count = %d, want %d", count, tt.wantCount)
+			}
+		})
+	}
+}
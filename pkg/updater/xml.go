@@ -0,0 +1,108 @@
+package updater
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// XML replaces the text of all elements matching an absolute selector like "/project/version".
+// Namespace prefixes are ignored. Only the text of the matching elements is rewritten, the rest of
+// the document is preserved byte for byte.
+func XML(content []byte, selector string, value string) ([]byte, int, error) {
+	path, err := parseXMLSelector(selector)
+	if err != nil {
+		return content, 0, err
+	}
+
+	type textRange struct {
+		start, end int
+	}
+
+	var ranges []textRange
+	var stack []string
+	matched := false
+	hasChildren := false
+	textStart := 0
+
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	for {
+		offset := int(decoder.InputOffset())
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return content, 0, fmt.Errorf("could not parse XML: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			stack = append(stack, t.Name.Local)
+			if matched {
+				hasChildren = true
+			} else if equalPath(stack, path) {
+				matched = true
+				hasChildren = false
+				textStart = int(decoder.InputOffset())
+			}
+		case xml.EndElement:
+			if matched && len(stack) == len(path) {
+				matched = false
+				if hasChildren {
+					return content, 0, fmt.Errorf("%s is no text element", selector)
+				}
+				// Self-closing elements have no text which could be replaced
+				if !bytes.HasSuffix(content[:textStart], []byte("/>")) {
+					ranges = append(ranges, textRange{start: textStart, end: offset})
+				}
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	var escaped bytes.Buffer
+	if err := xml.EscapeText(&escaped, []byte(value)); err != nil {
+		return content, 0, err
+	}
+
+	// Replace from the end of the document so earlier offsets stay valid
+	for i := len(ranges) - 1; i >= 0; i-- {
+		start, end := ranges[i].start, ranges[i].end
+
+		// Keep the whitespace around the text
+		text := string(content[start:end])
+		start += len(text) - len(strings.TrimLeft(text, " \t\r\n"))
+		end -= len(text) - len(strings.TrimRight(text, " \t\r\n"))
+		if end < start {
+			end = start
+		}
+
+		content = replaceRange(content, start, end, escaped.String())
+	}
+
+	return content, len(ranges), nil
+}
+
+// parseXMLSelector splits an absolute selector like "/project/version" into the local element names
+func parseXMLSelector(selector string) ([]string, error) {
+	if !strings.HasPrefix(selector, "/") || strings.HasPrefix(selector, "//") {
+		return nil, fmt.Errorf("invalid selector %q: only absolute paths like /project/version are supported", selector)
+	}
+
+	var path []string
+	for _, element := range strings.Split(strings.Trim(selector, "/"), "/") {
+		if element == "" {
+			return nil, fmt.Errorf("invalid selector %q: empty element", selector)
+		}
+		// Ignore namespace prefixes
+		if i := strings.Index(element, ":"); i >= 0 {
+			element = element[i+1:]
+		}
+		path = append(path, element)
+	}
+	return path, nil
+}
@@ -0,0 +1,136 @@
+package updater
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// YAML replaces the scalar value at the selector in every document of a YAML file, keeping
+// comments, quoting and the rest of the file untouched
+func YAML(content []byte, selector string, value string) ([]byte, int, error) {
+	path, err := parseSelector(selector)
+	if err != nil {
+		return content, 0, err
+	}
+
+	var targets []*yaml.Node
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var document yaml.Node
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return content, 0, fmt.Errorf("could not parse YAML: %w", err)
+		}
+
+		if node := findYAMLNode(&document, path); node != nil {
+			if node.Kind != yaml.ScalarNode {
+				return content, 0, fmt.Errorf("%s is no scalar value", selector)
+			}
+			targets = append(targets, node)
+		}
+	}
+
+	// Replace from the end of the file so earlier offsets stay valid
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].Line > targets[j].Line || (targets[i].Line == targets[j].Line && targets[i].Column > targets[j].Column)
+	})
+
+	for _, node := range targets {
+		start := yamlOffset(content, node.Line, node.Column)
+		end, replacement, err := yamlScalarEnd(content, start, node, value)
+		if err != nil {
+			return content, 0, err
+		}
+		content = replaceRange(content, start, end, replacement)
+	}
+
+	return content, len(targets), nil
+}
+
+func findYAMLNode(node *yaml.Node, path []pathElement) *yaml.Node {
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil
+		}
+		return findYAMLNode(node.Content[0], path)
+	}
+
+	if len(path) == 0 {
+		return node
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		if path[0].IsIndex {
+			return nil
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == path[0].Key {
+				return findYAMLNode(node.Content[i+1], path[1:])
+			}
+		}
+	case yaml.SequenceNode:
+		if path[0].IsIndex && path[0].Index < len(node.Content) {
+			return findYAMLNode(node.Content[path[0].Index], path[1:])
+		}
+	}
+	return nil
+}
+
+// yamlOffset converts the 1-based line and column of a node into a byte offset
+func yamlOffset(content []byte, line int, column int) int {
+	offset := 0
+	for l := 1; l < line; l++ {
+		next := bytes.IndexByte(content[offset:], '\n')
+		if next < 0 {
+			return len(content)
+		}
+		offset += next + 1
+	}
+
+	for c := 1; c < column && offset < len(content); c++ {
+		_, size := utf8.DecodeRune(content[offset:])
+		offset += size
+	}
+	return offset
+}
+
+// yamlScalarEnd returns the end offset of the scalar starting at start and its replacement in the same style
+func yamlScalarEnd(content []byte, start int, node *yaml.Node, value string) (int, string, error) {
+	switch node.Style {
+	case yaml.DoubleQuotedStyle:
+		for i := start + 1; i < len(content); i++ {
+			if content[i] == '\\' {
+				i++
+				continue
+			}
+			if content[i] == '"' {
+				return i + 1, `"` + value + `"`, nil
+			}
+		}
+	case yaml.SingleQuotedStyle:
+		for i := start + 1; i < len(content); i++ {
+			if content[i] == '\'' {
+				if i+1 < len(content) && content[i+1] == '\'' {
+					i++
+					continue
+				}
+				return i + 1, "'" + value + "'", nil
+			}
+		}
+	case 0:
+		if !strings.Contains(node.Value, "\n") && bytes.HasPrefix(content[start:], []byte(node.Value)) {
+			return start + len(node.Value), value, nil
+		}
+	}
+	return 0, "", fmt.Errorf("unsupported YAML scalar at line %d", node.Line)
+}
@@ -0,0 +1,55 @@
+package versioning
+
+import (
+	"fmt"
+	"github.com/git-releaser/git-releaser/pkg/config"
+	"github.com/git-releaser/git-releaser/pkg/helpers"
+	"github.com/thenativeweb/get-next-version/conventionalcommits"
+)
+
+const (
+	BumpPatch = "patch"
+	BumpMinor = "minor"
+	BumpMajor = "major"
+)
+
+// Bump returns the kind of version bump from the current to the next version
+func Bump(versions config.Versions) string {
+	switch {
+	case versions.NextVersion.Major() != versions.CurrentVersion.Major():
+		return BumpMajor
+	case versions.NextVersion.Minor() != versions.CurrentVersion.Minor():
+		return BumpMinor
+	}
+	return BumpPatch
+}
+
+// AutoMergeAllowed decides with the auto merge policy whether the release pull request of the next version
+// is merged automatically. If not, the reason is returned.
+func AutoMergeAllowed(policy config.AutoMergeConfig, versions config.Versions) (bool, string) {
+	if !policy.Enabled {
+		return false, "auto merge is disabled"
+	}
+	if !versions.HasNextVersion {
+		return false, "there is no next version"
+	}
+
+	bumps := policy.Bumps
+	if len(bumps) == 0 {
+		bumps = []string{BumpPatch}
+	}
+	bump := Bump(versions)
+	if !helpers.Contains(bumps, bump) {
+		return false, fmt.Sprintf("%s releases are not merged automatically", bump)
+	}
+
+	if !policy.AllowBreaking {
+		for _, commit := range versions.Commits {
+			commitType, err := conventionalcommits.CommitMessageToType(commit.Message)
+			if err == nil && commitType == conventionalcommits.BreakingChange {
+				return false, "the release contains breaking changes"
+			}
+		}
+	}
+	return true, ""
+}
@@ -0,0 +1,51 @@
+package versioning
+
+import (
+	"github.com/Masterminds/semver"
+	"github.com/git-releaser/git-releaser/pkg/config"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"testing"
+)
+
+func TestAutoMergeAllowed(t *testing.T) {
+	fix := object.Commit{Message: "fix: handle empty input"}
+	feature := object.Commit{Message: "feat: add option"}
+	breaking := object.Commit{Message: "feat!: remove option"}
+
+	tests := []struct {
+		name    string
+		policy  config.AutoMergeConfig
+		current string
+		next    string
+		commits []object.Commit
+		want    bool
+	}{
+		{name: "disabled", policy: config.AutoMergeConfig{}, current: "1.0.0", next: "1.0.1", commits: []object.Commit{fix}},
+		{name: "patch by default", policy: config.AutoMergeConfig{Enabled: true}, current: "1.0.0", next: "1.0.1", commits: []object.Commit{fix}, want: true},
+		{name: "minor not allowed by default", policy: config.AutoMergeConfig{Enabled: true}, current: "1.0.0", next: "1.1.0", commits: []object.Commit{feature}},
+		{name: "minor allowed", policy: config.AutoMergeConfig{Enabled: true, Bumps: []string{"patch", "minor"}}, current: "1.0.0", next: "1.1.0", commits: []object.Commit{fix, feature}, want: true},
+		{name: "major not allowed", policy: config.AutoMergeConfig{Enabled: true, Bumps: []string{"patch", "minor"}}, current: "1.2.0", next: "2.0.0", commits: []object.Commit{breaking}},
+		{name: "breaking change in a minor bump", policy: config.AutoMergeConfig{Enabled: true, Bumps: []string{"minor"}}, current: "0.2.0", next: "0.3.0", commits: []object.Commit{breaking}},
+		{name: "breaking change allowed", policy: config.AutoMergeConfig{Enabled: true, Bumps: []string{"minor"}, AllowBreaking: true}, current: "0.2.0", next: "0.3.0", commits: []object.Commit{breaking}, want: true},
+		{name: "breaking change clamped to a patch", policy: config.AutoMergeConfig{Enabled: true}, current: "1.4.2", next: "1.4.3", commits: []object.Commit{breaking}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			versions := config.Versions{
+				CurrentVersion: *semver.MustParse(tt.current),
+				NextVersion:    *semver.MustParse(tt.next),
+				HasNextVersion: true,
+				Commits:        tt.commits,
+			}
+
+			got, reason := AutoMergeAllowed(tt.policy, versions)
+			if got != tt.want {
+				t.Errorf("AutoMergeAllowed() = %v (%s), want %v", got, reason, tt.want)
+			}
+			if !got && reason == "" {
+				t.Errorf("AutoMergeAllowed() gave no reason")
+			}
+		})
+	}
+}
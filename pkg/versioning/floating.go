@@ -0,0 +1,57 @@
+package versioning
+
+import (
+	"fmt"
+	"github.com/Masterminds/semver"
+	"regexp"
+	"strings"
+)
+
+// releaseTagRegex matches complete release versions, so floating tags like v1 aren't taken for v1.0.0
+var releaseTagRegex = regexp.MustCompile(`^[0-9]+\.[0-9]+\.[0-9]+$`)
+
+// FloatingTags returns the floating tags vMAJOR and vMAJOR.MINOR of version, which follow its prefix. A
+// floating tag is skipped if tags contain a higher release of its major or minor version, so it is never
+// moved backwards, e.g. v1 stays at v1.4.0 when v1.3.5 is released. Prereleases have no floating tags.
+func FloatingTags(version semver.Version, tags []string) (move []string, skipped []string) {
+	if version.Prerelease() != "" {
+		return nil, nil
+	}
+
+	prefix := strings.TrimSuffix(version.Original(), version.String())
+	if prefix == version.Original() {
+		prefix = ""
+	}
+
+	highestMajor, highestMinor := &version, &version
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag, prefix) || !releaseTagRegex.MatchString(strings.TrimPrefix(tag, prefix)) {
+			continue
+		}
+		release, err := semver.NewVersion(strings.TrimPrefix(tag, prefix))
+		if err != nil || release.Major() != version.Major() {
+			continue
+		}
+
+		if release.GreaterThan(highestMajor) {
+			highestMajor = release
+		}
+		if release.Minor() == version.Minor() && release.GreaterThan(highestMinor) {
+			highestMinor = release
+		}
+	}
+
+	majorTag := fmt.Sprintf("%s%d", prefix, version.Major())
+	minorTag := fmt.Sprintf("%s%d.%d", prefix, version.Major(), version.Minor())
+	for _, floating := range []struct {
+		tag     string
+		highest *semver.Version
+	}{{majorTag, highestMajor}, {minorTag, highestMinor}} {
+		if floating.highest.GreaterThan(&version) {
+			skipped = append(skipped, floating.tag)
+		} else {
+			move = append(move, floating.tag)
+		}
+	}
+	return move, skipped
+}
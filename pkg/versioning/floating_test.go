@@ -0,0 +1,35 @@
+package versioning
+
+import (
+	"github.com/Masterminds/semver"
+	"reflect"
+	"testing"
+)
+
+func TestFloatingTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		version     string
+		tags        []string
+		wantMove    []string
+		wantSkipped []string
+	}{
+		{name: "first release", version: "v1.0.0", wantMove: []string{"v1", "v1.0"}},
+		{name: "newer minor", version: "v1.4.0", tags: []string{"v1", "v1.3", "v1.3.2", "v1.4.0"}, wantMove: []string{"v1", "v1.4"}},
+		{name: "patch of an older minor", version: "v1.3.5", tags: []string{"v1", "v1.3", "v1.3.4", "v1.4.0"}, wantMove: []string{"v1.3"}, wantSkipped: []string{"v1"}},
+		{name: "patch of an older major", version: "v1.4.1", tags: []string{"v1.4.0", "v2.0.0", "v2"}, wantMove: []string{"v1", "v1.4"}},
+		{name: "older release again", version: "v1.3.4", tags: []string{"v1.3.5"}, wantSkipped: []string{"v1", "v1.3"}},
+		{name: "without prefix", version: "2.1.0", tags: []string{"2.0.0", "v2.5.0"}, wantMove: []string{"2", "2.1"}},
+		{name: "ignores prereleases and other tags", version: "v1.2.0", tags: []string{"v1.3.0-rc.1", "v1.9", "latest"}, wantMove: []string{"v1", "v1.2"}},
+		{name: "prerelease", version: "v2.0.0-rc.1", tags: []string{"v1.0.0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			move, skipped := FloatingTags(*semver.MustParse(tt.version), tt.tags)
+			if !reflect.DeepEqual(move, tt.wantMove) || !reflect.DeepEqual(skipped, tt.wantSkipped) {
+				t.Errorf("FloatingTags() = %v, %v, want %v, %v", move, skipped, tt.wantMove, tt.wantSkipped)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package versioning
+
+import (
+	"fmt"
+	"github.com/Masterminds/semver"
+	"github.com/git-releaser/git-releaser/pkg/config"
+	"github.com/git-releaser/git-releaser/pkg/helpers"
+	"path"
+	"regexp"
+)
+
+var branchVersionRegex = regexp.MustCompile(`^v?([0-9]+(\.[0-9]+)?)$`)
+
+// findReleaseLine returns the first release line whose pattern matches branch
+func findReleaseLine(lines []config.ReleaseLine, branch string) (config.ReleaseLine, bool) {
+	for _, line := range lines {
+		if helpers.MatchGlob(line.Branch, branch) {
+			return line, true
+		}
+	}
+	return config.ReleaseLine{}, false
+}
+
+// ReleaseLineConstraint returns the version constraint of the release line of branch, or an empty string if
+// the branch has no constrained release line. Without configured versions, the constraint is derived from
+// the last part of the branch name, e.g. 1.4.x for release/1.4 and 2.x for release/v2.
+func ReleaseLineConstraint(lines []config.ReleaseLine, branch string) string {
+	line, ok := findReleaseLine(lines, branch)
+	if !ok {
+		return ""
+	}
+	if line.Versions != "" {
+		return line.Versions
+	}
+
+	match := branchVersionRegex.FindStringSubmatch(path.Base(branch))
+	if match == nil {
+		return ""
+	}
+	return match[1] + ".x"
+}
+
+// ApplyReleaseLine restricts the next version to the release line of branch. On maintenance lines a minor
+// or major version bump becomes a patch release. An error is returned if the next version is outside of
+// the versions of the line.
+func ApplyReleaseLine(versions config.Versions, lines []config.ReleaseLine, branch string) (config.Versions, error) {
+	line, ok := findReleaseLine(lines, branch)
+	if !ok || !versions.HasNextVersion {
+		return versions, nil
+	}
+
+	current, next := versions.CurrentVersion, versions.NextVersion
+	if line.Maintenance && (next.Major() != current.Major() || next.Minor() != current.Minor()) {
+		patch := current.IncPatch()
+		fmt.Printf("%s is a maintenance branch, releasing %s instead of %s\n", branch, patch.Original(), next.Original())
+		versions.NextVersion = patch
+	}
+
+	constraint := ReleaseLineConstraint(lines, branch)
+	if constraint == "" {
+		return versions, nil
+	}
+
+	constraints, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return versions, fmt.Errorf("invalid versions %q of release line %s: %w", constraint, line.Branch, err)
+	}
+	if !constraints.Check(&versions.NextVersion) {
+		return versions, fmt.Errorf("version %s is outside of the versions %s of branch %s", versions.NextVersion.Original(), constraint, branch)
+	}
+	return versions, nil
+}
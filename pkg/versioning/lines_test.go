@@ -0,0 +1,73 @@
+package versioning
+
+import (
+	"github.com/Masterminds/semver"
+	"github.com/git-releaser/git-releaser/pkg/config"
+	"testing"
+)
+
+var testReleaseLines = []config.ReleaseLine{
+	{Branch: "main", Versions: ">=2.0.0"},
+	{Branch: "release/*", Maintenance: true},
+	{Branch: "legacy", Versions: "~0.9", Maintenance: true},
+	{Branch: "next"},
+}
+
+func TestReleaseLineConstraint(t *testing.T) {
+	tests := []struct {
+		branch string
+		want   string
+	}{
+		{branch: "main", want: ">=2.0.0"},
+		{branch: "release/1.4", want: "1.4.x"},
+		{branch: "release/v2", want: "2.x"},
+		{branch: "release/candidate", want: ""},
+		{branch: "legacy", want: "~0.9"},
+		{branch: "next", want: ""},
+		{branch: "feature/x", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := ReleaseLineConstraint(testReleaseLines, tt.branch); got != tt.want {
+			t.Errorf("ReleaseLineConstraint(%q) = %q, want %q", tt.branch, got, tt.want)
+		}
+	}
+}
+
+func TestApplyReleaseLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		branch  string
+		current string
+		next    string
+		want    string
+		wantErr bool
+	}{
+		{name: "minor bump on maintenance branch", branch: "release/1.4", current: "v1.4.2", next: "v1.5.0", want: "v1.4.3"},
+		{name: "major bump on maintenance branch", branch: "release/1.4", current: "v1.4.2", next: "v2.0.0", want: "v1.4.3"},
+		{name: "patch on maintenance branch", branch: "release/1.4", current: "v1.4.2", next: "v1.4.3", want: "v1.4.3"},
+		{name: "maintenance branch of another line", branch: "release/1.3", current: "v1.4.2", next: "v1.4.3", wantErr: true},
+		{name: "main within its versions", branch: "main", current: "v2.1.0", next: "v2.2.0", want: "v2.2.0"},
+		{name: "main outside of its versions", branch: "main", current: "v1.4.2", next: "v1.5.0", wantErr: true},
+		{name: "line without constraint", branch: "next", current: "v2.1.0", next: "v3.0.0", want: "v3.0.0"},
+		{name: "branch without line", branch: "feature/x", current: "v2.1.0", next: "v3.0.0", want: "v3.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			versions := config.Versions{
+				CurrentVersion: *semver.MustParse(tt.current),
+				NextVersion:    *semver.MustParse(tt.next),
+				HasNextVersion: true,
+			}
+
+			got, err := ApplyReleaseLine(versions, testReleaseLines, tt.branch)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ApplyReleaseLine() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.NextVersion.Original() != tt.want {
+				t.Errorf("ApplyReleaseLine() = %s, want %s", got.NextVersion.Original(), tt.want)
+			}
+		})
+	}
+}
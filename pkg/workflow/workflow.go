@@ -0,0 +1,332 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"github.com/git-releaser/git-releaser/pkg/assets"
+	"github.com/git-releaser/git-releaser/pkg/changelog"
+	"github.com/git-releaser/git-releaser/pkg/config"
+	"github.com/git-releaser/git-releaser/pkg/git"
+	"github.com/git-releaser/git-releaser/pkg/git/common"
+	"github.com/git-releaser/git-releaser/pkg/naming"
+	"github.com/git-releaser/git-releaser/pkg/versioning"
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/osfs"
+	"strconv"
+)
+
+// Workflow runs the steps of a release against a git provider. The release command creates the release
+// of the version in the manifest, the release-pr command opens the release pull request of the next
+// version and the update command runs both.
+type Workflow struct {
+	Provider git.Provider
+	Config   config.Config
+	Versions config.Versions
+	// Filesystem is searched for the nested Go modules to tag
+	Filesystem billy.Filesystem
+	// NewUpdateProvider returns the provider for the repository of a config update
+	NewUpdateProvider func(update config.ConfigUpdate) git.Provider
+}
+
+// New creates the provider for the repository and determines the current and next version. The settings
+// of the configuration file are added to the given git configuration, the repositories of the config updates
+// are accessed with the same credentials.
+func New(conf config.Config, gitConfig git.Config) (*Workflow, error) {
+	if conf.TargetBranch == "" {
+		conf.TargetBranch = "main"
+	}
+
+	updateConfig := git.Config{
+		Provider:         gitConfig.Provider,
+		AccessToken:      gitConfig.AccessToken,
+		UserId:           gitConfig.UserId,
+		ApiUrl:           gitConfig.ApiUrl,
+		AdditionalConfig: gitConfig.AdditionalConfig,
+		Author:           conf.Author,
+		Signing:          conf.Signing,
+		DryRun:           gitConfig.DryRun,
+	}
+
+	// A draft release may be created for a commit instead of the target branch, the propagation targets
+	// keep the branch
+	for _, target := range conf.PropagationTargets {
+		if target.TargetBranch == "" {
+			target.TargetBranch = conf.TargetBranch
+		}
+		gitConfig.PropagationTargets = append(gitConfig.PropagationTargets, target)
+	}
+	gitConfig.Author = conf.Author
+	gitConfig.Signing = conf.Signing
+	gitConfig.Draft = conf.Release.Draft
+	gitConfig.VersionConstraint = versioning.ReleaseLineConstraint(conf.ReleaseLines, conf.TargetBranch)
+	gitConfig.ConfigUpdates = conf.ConfigUpdates
+	gitConfig.Changelog = conf.Changelog
+	gitConfig.PullRequest = conf.PullRequest
+
+	g := git.NewGitClient(gitConfig)
+
+	v := versioning.NewVersion(conf.Versioning)
+
+	err := v.SetNextVersion()
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	versions, err := versioning.ApplyReleaseLine(v.GetVersions(), conf.ReleaseLines, conf.TargetBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Workflow{
+		Provider:   g,
+		Config:     conf,
+		Versions:   versions,
+		Filesystem: osfs.New("."),
+		NewUpdateProvider: func(update config.ConfigUpdate) git.Provider {
+			c := updateConfig
+			c.ProjectUrl = update.Repository
+			c.AdditionalConfig = make(map[string]string)
+			for key, value := range updateConfig.AdditionalConfig {
+				c.AdditionalConfig[key] = value
+			}
+			if update.ProjectId != 0 {
+				c.AdditionalConfig["projectId"] = strconv.Itoa(update.ProjectId)
+			}
+			return git.NewGitClient(c)
+		},
+	}, nil
+}
+
+// Update creates the release of the version in the manifest if it is due, the release pull request of
+// the next version is created or updated otherwise
+func (w *Workflow) Update() error {
+	released, err := w.Release()
+	if err != nil || released {
+		return err
+	}
+	return w.ReleasePullRequest()
+}
+
+// Release creates the release of the version in the manifest unless it has been released already, see
+// git.DecideRelease. It reports whether the version was due for release.
+func (w *Workflow) Release() (bool, error) {
+	current := w.Versions.CurrentVersion.Original()
+
+	releaseExists, err := w.Provider.CheckRelease(w.Versions)
+	if err != nil {
+		fmt.Println("Could not check for Release: " + err.Error())
+	}
+
+	releaseBranch := naming.CreateBranchName(w.Config.BranchPrefix, current)
+	pullRequest, err := w.Provider.GetMergedPullRequest(releaseBranch, w.Config.TargetBranch)
+	if err != nil {
+		fmt.Println("Could not check for the release pull request: " + err.Error())
+	}
+
+	switch git.DecideRelease(pullRequest, releaseExists) {
+	case git.ReleaseMarkTagged:
+		w.markReleaseTagged(pullRequest)
+		return false, nil
+	case git.ReleaseNone:
+		if !releaseExists {
+			fmt.Println("The release pull request of " + current + " is labeled " + naming.LabelTagged + ", not releasing it again")
+		}
+		return false, nil
+	}
+
+	fmt.Println("Running release for version " + current)
+	err = w.createSignedReleaseTag()
+	if err != nil {
+		return true, err
+	}
+
+	target, err := w.releaseTarget()
+	if err != nil {
+		return true, err
+	}
+
+	err = w.Provider.CreateRelease(target, w.Versions, "")
+	if err != nil {
+		return true, err
+	}
+
+	w.markReleaseTagged(pullRequest)
+	w.createGoModuleTags(target)
+	w.moveFloatingTags()
+	w.uploadAssets()
+	w.updateConfigs()
+	return true, nil
+}
+
+// ReleasePullRequest commits the next version to the release branch and creates or updates the release
+// pull request for it
+func (w *Workflow) ReleasePullRequest() error {
+	if !w.Versions.HasNextVersion {
+		fmt.Println("No new version will be created")
+		return nil
+	}
+
+	next := w.Versions.NextVersion.Original()
+	content := fmt.Sprintf(`{"version": "%s"}`, next)
+	changes, err := w.Provider.PrepareManifest(content, w.Versions, w.Config.ExtraFiles)
+	if err != nil {
+		return errors.New("Could not update the Repository: " + err.Error())
+	}
+
+	branch, err := w.Provider.CheckCreateBranch(w.Config.TargetBranch, next, w.Config.BranchPrefix)
+	if err != nil {
+		return errors.New("Could not check for Branch: " + err.Error())
+	}
+
+	err = w.Provider.CommitManifest(branch, changes, w.Versions)
+	if err != nil {
+		return errors.New("Could not update the Repository: " + err.Error())
+	}
+
+	err = w.Provider.CheckCreateReleasePullRequest(branch, w.Config.TargetBranch, w.Versions)
+	if err != nil {
+		return errors.New("Could not create the release pull request: " + err.Error())
+	}
+
+	w.enableAutoMerge(branch)
+	return nil
+}
+
+// enableAutoMerge merges the release pull request automatically if the auto merge policy allows it
+func (w *Workflow) enableAutoMerge(branch string) {
+	if !w.Config.AutoMerge.Enabled {
+		return
+	}
+
+	allowed, reason := versioning.AutoMergeAllowed(w.Config.AutoMerge, w.Versions)
+	if !allowed {
+		fmt.Println("Not merging the release pull request automatically, " + reason)
+		return
+	}
+
+	err := w.Provider.EnableAutoMerge(branch, w.Config.TargetBranch, w.Config.AutoMerge.Method)
+	if err != nil {
+		fmt.Println("Could not enable auto merge: " + err.Error())
+	}
+}
+
+// markReleaseTagged replaces the pending label of the merged release pull request, if there is one
+func (w *Workflow) markReleaseTagged(pullRequest *changelog.PullRequest) {
+	if pullRequest == nil {
+		return
+	}
+
+	err := w.Provider.SetPullRequestLabels(pullRequest.Number, []string{naming.LabelTagged}, []string{naming.LabelPending})
+	if err != nil {
+		fmt.Println("Could not label the release pull request: " + err.Error())
+	}
+}
+
+// createSignedReleaseTag pushes the release tag as a signed tag if configured, the release is created for it afterwards
+func (w *Workflow) createSignedReleaseTag() error {
+	if !w.Config.Signing.Tags {
+		return nil
+	}
+
+	tag := w.Versions.CurrentVersion.Original()
+	err := w.Provider.CreateSignedTag(tag, w.Config.TargetBranch, "Release "+tag)
+	if err != nil {
+		return errors.New("Could not create signed tag " + tag + ": " + err.Error())
+	}
+	return nil
+}
+
+// releaseTarget returns the ref the release is created for. The tag of a draft release is only created when
+// it is published, the draft points to the current commit of the target branch so that later commits aren't
+// released with it.
+func (w *Workflow) releaseTarget() (string, error) {
+	if !w.Config.Release.Draft {
+		return w.Config.TargetBranch, nil
+	}
+
+	sha, err := w.Provider.GetCommitSHA(w.Config.TargetBranch)
+	if err != nil {
+		return "", errors.New("Could not resolve the commit of " + w.Config.TargetBranch + ": " + err.Error())
+	}
+	return sha, nil
+}
+
+// createGoModuleTags tags the nested Go modules at the commit of the release, target is the commit of a
+// draft release
+func (w *Workflow) createGoModuleTags(target string) {
+	tags, err := common.GoModuleTags(w.Filesystem, w.Config.ExtraFiles, w.Versions.CurrentVersion)
+	if err != nil {
+		fmt.Println("Could not find the Go modules: " + err.Error())
+		return
+	}
+
+	// The tag of a draft release may not exist yet, the module tags point to its commit then
+	ref := w.Versions.CurrentVersion.Original()
+	if w.Config.Release.Draft && !w.Config.Signing.Tags {
+		ref = target
+	}
+
+	for _, tag := range tags {
+		if w.Config.Signing.Tags {
+			err = w.Provider.CreateSignedTag(tag, ref, "Release "+tag)
+		} else {
+			err = w.Provider.CreateTag(tag, ref)
+		}
+		if err != nil {
+			fmt.Println("Could not create tag " + tag + ": " + err.Error())
+		}
+	}
+}
+
+// moveFloatingTags moves the floating tags to the release, a draft release moves them when it is published
+func (w *Workflow) moveFloatingTags() {
+	if !w.Config.Release.FloatingTags || w.Config.Release.Draft {
+		return
+	}
+
+	err := git.MoveFloatingTags(w.Provider, w.Versions.CurrentVersion, w.Versions.CurrentVersion.Original())
+	if err != nil {
+		fmt.Println("Could not move the floating tags: " + err.Error())
+	}
+}
+
+func (w *Workflow) uploadAssets() {
+	if len(w.Config.Assets) == 0 {
+		return
+	}
+
+	err := assets.Upload(w.Provider, w.Versions.CurrentVersion.Original(), w.Config.Assets, w.Config.Checksums)
+	if err != nil {
+		fmt.Println("Could not upload the release assets: " + err.Error())
+	}
+}
+
+// updateConfigs replaces the version in the repositories of the config updates and creates merge requests for it
+func (w *Workflow) updateConfigs() {
+	for _, update := range w.Config.ConfigUpdates {
+		r := w.NewUpdateProvider(update)
+
+		replacements := []config.TagReplacement{{
+			SearchTag: update.SearchTag,
+			Value:     w.Versions.CurrentVersion.String(),
+			Files:     update.Files,
+			Exclude:   update.Exclude,
+		}}
+		branch := naming.CreateFileUpdateBranchName(replacements)
+
+		changeset, err := r.ReplaceTags(replacements)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		err = r.CommitFile(branch, changeset)
+		if err != nil {
+			fmt.Println("Could not update the Repository: " + err.Error())
+		}
+
+		err = r.CheckCreateFileMergeRequest(branch, w.Config.TargetBranch, naming.CreateFileUpdateTitle(replacements), naming.CreateFileUpdateDescription(replacements))
+		if err != nil {
+			fmt.Println("Could not create the Merge Request: " + err.Error())
+		}
+	}
+}
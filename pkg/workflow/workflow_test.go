@@ -0,0 +1,240 @@
+package workflow
+
+import (
+	"errors"
+	"github.com/Masterminds/semver"
+	"github.com/git-releaser/git-releaser/pkg/changelog"
+	"github.com/git-releaser/git-releaser/pkg/config"
+	"github.com/git-releaser/git-releaser/pkg/git"
+	"github.com/git-releaser/git-releaser/pkg/git/common"
+	"github.com/git-releaser/git-releaser/pkg/naming"
+	"github.com/go-git/go-billy/v5/memfs"
+	"reflect"
+	"testing"
+)
+
+// fakeProvider records the calls of the workflow, the methods which aren't overridden panic
+type fakeProvider struct {
+	git.Provider
+	releaseExists bool
+	pullRequest   *changelog.PullRequest
+	releaseErr    error
+	releaseTarget string
+	manifest      string
+	manifestErr   error
+	calls         []string
+}
+
+func (f *fakeProvider) CheckRelease(versions config.Versions) (bool, error) {
+	return f.releaseExists, nil
+}
+
+func (f *fakeProvider) GetMergedPullRequest(source string, target string) (*changelog.PullRequest, error) {
+	return f.pullRequest, nil
+}
+
+func (f *fakeProvider) SetPullRequestLabels(number int, add []string, remove []string) error {
+	f.calls = append(f.calls, "label "+add[0])
+	return nil
+}
+
+func (f *fakeProvider) CreateRelease(baseBranch string, versions config.Versions, description string) error {
+	f.calls = append(f.calls, "release "+versions.CurrentVersion.Original())
+	f.releaseTarget = baseBranch
+	return f.releaseErr
+}
+
+func (f *fakeProvider) GetCommitSHA(ref string) (string, error) {
+	return "3f1c2a9", nil
+}
+
+func (f *fakeProvider) CheckCreateBranch(baseBranch string, targetVersion string, prefix string) (string, error) {
+	branch := naming.CreateBranchName(prefix, targetVersion)
+	f.calls = append(f.calls, "branch "+branch)
+	return branch, nil
+}
+
+func (f *fakeProvider) PrepareManifest(content string, versions config.Versions, extraFiles []config.ExtraFileConfig) (common.ManifestChanges, error) {
+	f.manifest = content
+	return common.ManifestChanges{}, f.manifestErr
+}
+
+func (f *fakeProvider) CommitManifest(branchName string, changes common.ManifestChanges, versions config.Versions) error {
+	f.calls = append(f.calls, "commit "+f.manifest)
+	return nil
+}
+
+func (f *fakeProvider) CheckCreateReleasePullRequest(source string, target string, versions config.Versions) error {
+	f.calls = append(f.calls, "pull request "+source+" "+target)
+	return nil
+}
+
+func (f *fakeProvider) EnableAutoMerge(source string, target string, method string) error {
+	f.calls = append(f.calls, "auto merge "+source)
+	return nil
+}
+
+func newVersions(current string, next string) config.Versions {
+	versions := config.Versions{CurrentVersion: *semver.MustParse(current)}
+	if next != "" {
+		versions.NextVersion = *semver.MustParse(next)
+		versions.HasNextVersion = true
+	}
+	return versions
+}
+
+func TestUpdate(t *testing.T) {
+	pending := &changelog.PullRequest{Number: 1, Labels: []string{naming.LabelPending}}
+	tagged := &changelog.PullRequest{Number: 1, Labels: []string{naming.LabelTagged}}
+
+	tests := []struct {
+		name     string
+		provider *fakeProvider
+		config   config.Config
+		versions config.Versions
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "merged release pull request",
+			provider: &fakeProvider{pullRequest: pending},
+			versions: newVersions("v1.0.0", "v1.1.0"),
+			want:     []string{"release v1.0.0", "label " + naming.LabelTagged},
+		},
+		{
+			name:     "failed release",
+			provider: &fakeProvider{releaseErr: errors.New("failed")},
+			versions: newVersions("v1.0.0", "v1.1.0"),
+			want:     []string{"release v1.0.0"},
+			wantErr:  true,
+		},
+		{
+			name:     "release exists for pending pull request",
+			provider: &fakeProvider{pullRequest: pending, releaseExists: true},
+			versions: newVersions("v1.0.0", "v1.1.0"),
+			want:     []string{"label " + naming.LabelTagged, "branch release-v1.1.0", `commit {"version": "v1.1.0"}`, "pull request release-v1.1.0 main"},
+		},
+		{
+			name:     "tagged pull request",
+			provider: &fakeProvider{pullRequest: tagged},
+			versions: newVersions("v1.0.0", "v1.1.0"),
+			want:     []string{"branch release-v1.1.0", `commit {"version": "v1.1.0"}`, "pull request release-v1.1.0 main"},
+		},
+		{
+			// No release branch is created when the extra files don't match their expectations
+			name:     "failed extra file expectations",
+			provider: &fakeProvider{releaseExists: true, manifestErr: errors.New("failed")},
+			versions: newVersions("v1.0.0", "v1.1.0"),
+			wantErr:  true,
+		},
+		{
+			name:     "no next version",
+			provider: &fakeProvider{releaseExists: true},
+			versions: newVersions("v1.0.0", ""),
+		},
+		{
+			name:     "auto merge",
+			provider: &fakeProvider{releaseExists: true},
+			config:   config.Config{AutoMerge: config.AutoMergeConfig{Enabled: true, Bumps: []string{"patch"}}},
+			versions: newVersions("v1.0.0", "v1.0.1"),
+			want:     []string{"branch release-v1.0.1", `commit {"version": "v1.0.1"}`, "pull request release-v1.0.1 main", "auto merge release-v1.0.1"},
+		},
+		{
+			name:     "auto merge not allowed",
+			provider: &fakeProvider{releaseExists: true},
+			config:   config.Config{AutoMerge: config.AutoMergeConfig{Enabled: true, Bumps: []string{"patch"}}},
+			versions: newVersions("v1.0.0", "v1.1.0"),
+			want:     []string{"branch release-v1.1.0", `commit {"version": "v1.1.0"}`, "pull request release-v1.1.0 main"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.TargetBranch = "main"
+			tt.config.BranchPrefix = "release"
+			w := &Workflow{
+				Provider:   tt.provider,
+				Config:     tt.config,
+				Versions:   tt.versions,
+				Filesystem: memfs.New(),
+			}
+
+			err := w.Update()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(tt.provider.calls, tt.want) {
+				t.Errorf("Update() calls = %q, want %q", tt.provider.calls, tt.want)
+			}
+		})
+	}
+}
+
+func TestRelease(t *testing.T) {
+	tests := []struct {
+		name          string
+		releaseExists bool
+		want          bool
+	}{
+		{name: "not released", want: true},
+		{name: "released", releaseExists: true, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fakeProvider{releaseExists: tt.releaseExists}
+			w := &Workflow{
+				Provider:   provider,
+				Config:     config.Config{TargetBranch: "main"},
+				Versions:   newVersions("v1.0.0", "v1.1.0"),
+				Filesystem: memfs.New(),
+			}
+
+			got, err := w.Release()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Release() = %v, want %v", got, tt.want)
+			}
+			// The release pull request of the next version is left to the release-pr command
+			for _, call := range provider.calls {
+				if call != "release v1.0.0" {
+					t.Errorf("Release() called %q", call)
+				}
+			}
+		})
+	}
+}
+
+func TestReleaseTarget(t *testing.T) {
+	tests := []struct {
+		name  string
+		draft bool
+		want  string
+	}{
+		{name: "release", want: "main"},
+		// The draft is pinned to the reviewed commit, later commits on main aren't released with it
+		{name: "draft", draft: true, want: "3f1c2a9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fakeProvider{}
+			w := &Workflow{
+				Provider:   provider,
+				Config:     config.Config{TargetBranch: "main", Release: config.ReleaseConfig{Draft: tt.draft}},
+				Versions:   newVersions("v1.0.0", "v1.1.0"),
+				Filesystem: memfs.New(),
+			}
+
+			_, err := w.Release()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if provider.releaseTarget != tt.want {
+				t.Errorf("release created for %q, want %q", provider.releaseTarget, tt.want)
+			}
+		})
+	}
+}